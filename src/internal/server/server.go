@@ -2,23 +2,44 @@ package server
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"log"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/merev/mcp-kubernetes-server/pkg/tools"
 )
 
 type Options struct {
-	DisableKubectl bool
-	DisableHelm    bool
-	DisableWrite   bool
-	DisableDelete  bool
-	Transport      string
-	Host           string
-	Port           int
+	DisableKubectl     bool
+	DisableHelm        bool
+	DisableWrite       bool
+	DisableDelete      bool
+	DisableExec        bool
+	DisableCp          bool
+	DisablePortForward bool
+	AllowNodeDebug     bool
+	AllowSecretReveal  bool
+	Protobuf           bool
+	Namespace          string
+	MaxResponseBytes   int
+	ToolTimeout        time.Duration
+	ConflictRetries    int
+	Transport          string
+	Host               string
+	Port               int
+	HTTPGzip           bool
+	ProtectedKinds     string
+	TLSCert            string
+	TLSKey             string
+	TLSClientCA        string
+	Insecure           bool
 }
 
 func Run() error {
@@ -30,26 +51,46 @@ func Run() error {
 		Version: "dev",
 	}, nil)
 
+	tools.SetUseProtobuf(opts.Protobuf)
+
 	// Equivalent to setup_client() in Python.
-	// We'll implement this once you provide kubeclient.py (config loading, in-cluster, etc).
+	// A cluster that is down at startup is not fatal: tools retry the setup on use.
+	// A missing configuration is.
 	if err := tools.SetupClient(context.Background()); err != nil {
-		return fmt.Errorf("setup k8s client: %w", err)
+		if !errors.Is(err, tools.ErrClusterUnreachable) {
+			return fmt.Errorf("setup k8s client: %w", err)
+		}
+		log.Printf("warning: %v (will retry on first tool call)", err)
 	}
 
-	registerReadTools(srv)
+	tools.SetDeleteDisabled(opts.DisableDelete)
+	tools.SetSecretRevealAllowed(opts.AllowSecretReveal)
+	tools.SetProtectedKinds(strings.Split(opts.ProtectedKinds, ","))
+	tools.SetDefaultNamespace(opts.Namespace)
+	tools.SetMaxResponseBytes(opts.MaxResponseBytes)
+	tools.SetToolTimeout(opts.ToolTimeout)
+	tools.SetConflictRetries(opts.ConflictRetries)
+	tools.SetStreamProgress(opts.Transport != "stdio")
+
+	registerReadTools(srv, opts)
 
 	if !opts.DisableWrite {
-		registerWriteTools(srv)
+		registerWriteTools(srv, opts)
+		// Node debug pods are privileged on the host; opt-in only.
+		if opts.AllowNodeDebug {
+			tools.AddTool(srv, "k8s_debug_node", "Start a privileged debug pod on a node (host PID/network, host root at /host)", tools.K8sDebugNode)
+		}
 	}
 	if !opts.DisableDelete {
 		registerDeleteTools(srv)
 	}
 
 	if !opts.DisableKubectl {
-		tools.RegisterKubectlTool(srv, opts.DisableWrite, opts.DisableDelete)
+		tools.RegisterKubectlTool(srv, opts.DisableWrite, opts.DisableDelete, opts.blockedKubectlSubcommands())
 	}
 	if !opts.DisableHelm {
 		tools.RegisterHelmTool(srv, opts.DisableWrite)
+		tools.RegisterHelmTemplateTool(srv, opts.DisableWrite)
 	}
 
 	switch opts.Transport {
@@ -61,7 +102,14 @@ func Run() error {
 		// In the Go SDK, Streamable HTTP is exposed via an HTTP handler.
 		// This is the closest match to your Python "sse" and "streamable-http" options.
 		// (We keep both flags for compatibility.)
-		addr := fmt.Sprintf("%s:%d", opts.Host, opts.Port)
+		addr := net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port))
+		tlsConfig, err := serverTLSConfig(opts)
+		if err != nil {
+			return err
+		}
+		if err := checkExposure(opts, tlsConfig, addr); err != nil {
+			return err
+		}
 
 		handler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
 			// You can decide later if you want per-request server instances.
@@ -69,74 +117,192 @@ func Run() error {
 			return srv
 		}, nil)
 
+		var h http.Handler = handler
+		if opts.HTTPGzip {
+			h = gzipHandler(handler)
+		}
+
+		if tlsConfig != nil {
+			hs := &http.Server{Addr: addr, Handler: h, TLSConfig: tlsConfig}
+			log.Printf("MCP Streamable HTTP listening on https://%s", addr)
+			return hs.ListenAndServeTLS(opts.TLSCert, opts.TLSKey)
+		}
 		log.Printf("MCP Streamable HTTP listening on http://%s", addr)
-		return http.ListenAndServe(addr, handler)
+		return http.ListenAndServe(addr, h)
 
 	default:
 		return fmt.Errorf("unsupported transport: %q (expected stdio|sse|streamable-http)", opts.Transport)
 	}
 }
 
+// blockedKubectlSubcommands keeps the kubectl tool from bypassing
+// --disable-exec, --disable-cp and --disable-portforward.
+func (o Options) blockedKubectlSubcommands() []string {
+	var blocked []string
+	if o.DisableExec {
+		blocked = append(blocked, "exec", "attach", "debug")
+	}
+	if o.DisableExec || o.DisableCp {
+		blocked = append(blocked, "cp") // kubectl cp runs tar over exec
+	}
+	if o.DisablePortForward {
+		blocked = append(blocked, "port-forward", "proxy")
+	}
+	return blocked
+}
+
+// defaultProtectedKinds are cluster-critical kinds whose deletion or patching
+// can take down more than one workload.
+const defaultProtectedKinds = "Namespace,PersistentVolume,CustomResourceDefinition,ClusterRole,ClusterRoleBinding,StorageClass,ValidatingWebhookConfiguration,MutatingWebhookConfiguration,APIService"
+
 func parseFlags() Options {
 	var opts Options
 	flag.BoolVar(&opts.DisableKubectl, "disable-kubectl", false, "Disable kubectl command execution")
 	flag.BoolVar(&opts.DisableHelm, "disable-helm", false, "Disable helm command execution")
 	flag.BoolVar(&opts.DisableWrite, "disable-write", false, "Disable write operations")
 	flag.BoolVar(&opts.DisableDelete, "disable-delete", false, "Disable delete operations")
+	flag.BoolVar(&opts.DisableExec, "disable-exec", false, "Disable running commands in containers: k8s_exec_command, k8s_exec_workload, k8s_debug, k8s_copy_pod and the exec-backed file tools k8s_cp, k8s_read_file, k8s_write_file (and kubectl exec/attach/debug/cp)")
+	flag.BoolVar(&opts.DisableCp, "disable-cp", false, "Disable copying files in or out of containers: k8s_cp, k8s_read_file, k8s_write_file (and kubectl cp)")
+	flag.BoolVar(&opts.DisablePortForward, "disable-portforward", false, "Disable k8s_port_forward (and kubectl port-forward/proxy)")
+	flag.BoolVar(&opts.AllowNodeDebug, "allow-node-debug", false, "Enable k8s_debug_node, which creates privileged pods on nodes (requires write operations)")
+	flag.BoolVar(&opts.AllowSecretReveal, "allow-secret-reveal", false, "Allow tools to return Secret values in clear text when asked (reveal_secrets)")
+	flag.StringVar(&opts.ProtectedKinds, "protected-kinds", defaultProtectedKinds, "Comma-separated kinds that tools only delete, patch, apply, label or otherwise change with confirm=true (empty = none)")
+	flag.BoolVar(&opts.Protobuf, "protobuf", false, "Use protobuf instead of JSON for built-in resource types (smaller, faster large lists)")
+	flag.StringVar(&opts.Namespace, "namespace", "", "Default namespace for tools called without a namespace (default \"default\")")
+	flag.IntVar(&opts.MaxResponseBytes, "max-response-bytes", 0, "Truncate tool output larger than this many bytes (0 = no limit)")
+	flag.IntVar(&opts.ConflictRetries, "conflict-retries", 3, "How often k8s_set_* tools re-read and re-apply their edit when an update hits a Conflict")
+	flag.DurationVar(&opts.ToolTimeout, "tool-timeout", 60*time.Second, "Default deadline for read tool calls (0 = none); tools that wait or stream use their own bounds")
 	flag.StringVar(&opts.Transport, "transport", "stdio", "Transport mechanism to use (stdio or sse or streamable-http)")
 	flag.StringVar(&opts.Host, "host", "127.0.0.1", "Host to use for sse or streamable-http server")
 	flag.IntVar(&opts.Port, "port", 8000, "Port to use for sse or streamable-http server")
+	flag.StringVar(&opts.TLSCert, "tls-cert", "", "PEM certificate file; with --tls-key serves sse or streamable-http over HTTPS")
+	flag.StringVar(&opts.TLSKey, "tls-key", "", "PEM private key file for --tls-cert")
+	flag.StringVar(&opts.TLSClientCA, "tls-client-ca", "", "PEM CA bundle; when set, HTTPS clients must present a certificate signed by it (mTLS)")
+	flag.BoolVar(&opts.Insecure, "insecure", false, "Allow sse or streamable-http on a non-loopback host without TLS")
+	flag.BoolVar(&opts.HTTPGzip, "http-gzip", true, "Gzip HTTP responses for clients that accept it (sse or streamable-http only)")
 	flag.Parse()
 	return opts
 }
 
-func registerReadTools(srv *mcp.Server) {
-	tools.AddTool(srv, "k8s_apis", "List Kubernetes APIs", tools.K8sApis)
-	tools.AddTool(srv, "k8s_crds", "List Kubernetes CRDs", tools.K8sCrds)
-	tools.AddTool(srv, "k8s_get", "Get Kubernetes resources", tools.K8sGet)
+// registerReadTools registers the read-only tools. Most get the --tool-timeout
+// deadline; the ones that wait or stream (logs, events, rollout/job/LB waits)
+// use AddTool and bound themselves.
+func registerReadTools(srv *mcp.Server, opts Options) {
+	tools.AddReadTool(srv, "k8s_current_context", "Current context, cluster, user and the namespace tools default to", tools.K8sCurrentContext)
+	tools.AddReadTool(srv, "k8s_set_namespace", "Set the default namespace for later tool calls in this session (in memory; kubeconfig is not changed)", tools.K8sSetNamespace)
+	tools.AddReadTool(srv, "k8s_cluster_info", "API server version, control-plane endpoint and readiness checks", tools.K8sClusterInfo)
+	tools.AddReadTool(srv, "k8s_apis", "List Kubernetes APIs", tools.K8sApis)
+	tools.AddReadTool(srv, "k8s_apiservices", "List aggregated APIServices and their availability, unavailable first", tools.K8sAPIServices)
+	tools.AddReadTool(srv, "k8s_crds", "List Kubernetes CRDs", tools.K8sCrds)
+	tools.AddReadTool(srv, "k8s_list_cr", "List custom resource instances by group and kind", tools.K8sListCR)
+	tools.AddReadTool(srv, "k8s_get", "Get Kubernetes resources", tools.K8sGet)
+	tools.AddReadTool(srv, "k8s_exists", "Check whether a resource exists (returns resourceVersion if it does)", tools.K8sExists)
+	tools.AddReadTool(srv, "k8s_get_field", "Get a single field of a resource by path (e.g. spec.replicas)", tools.K8sGetField)
+	tools.AddReadTool(srv, "k8s_export", "Export a live object as a clean, re-appliable YAML manifest", tools.K8sExport)
+	tools.AddReadTool(srv, "k8s_diff_namespaces", "Compare one resource kind across two namespaces (only-in-a/b and per-object field diffs)", tools.K8sDiffNamespaces)
+	tools.AddReadTool(srv, "k8s_object_diff", "Diff a manifest against the live object (read-only)", tools.K8sObjectDiff)
+	tools.AddReadTool(srv, "k8s_get_all", "List all resources in a namespace", tools.K8sGetAll)
+	tools.AddReadTool(srv, "k8s_namespace_overview", "Namespace status, quota usage, object counts and termination blockers", tools.K8sNamespaceOverview)
+	tools.AddReadTool(srv, "k8s_status", "Normalized health status of a resource", tools.K8sStatus)
 	tools.AddTool(srv, "k8s_rollout_status", "Get rollout status", tools.K8sRolloutStatus)
-	tools.AddTool(srv, "k8s_rollout_history", "Get rollout history", tools.K8sRolloutHistory)
-	tools.AddTool(srv, "k8s_top_nodes", "Top nodes", tools.K8sTopNodes)
-	tools.AddTool(srv, "k8s_top_pods", "Top pods", tools.K8sTopPods)
-	tools.AddTool(srv, "k8s_describe", "Describe Kubernetes resources", tools.K8sDescribe)
+	tools.AddReadTool(srv, "k8s_rollout_history", "Get rollout history", tools.K8sRolloutHistory)
+	tools.AddReadTool(srv, "k8s_rollout_diff", "Diff the pod templates of two deployment revisions", tools.K8sRolloutDiff)
+	tools.AddTool(srv, "k8s_rollout_watch", "Watch a rollout with pod and event timeline", tools.K8sRolloutWatch)
+	tools.AddReadTool(srv, "k8s_service_endpoints", "Show the endpoints (pods) behind a service", tools.K8sServiceEndpoints)
+	tools.AddTool(srv, "k8s_wait_loadbalancer", "Wait for a LoadBalancer service to get an external IP/hostname", tools.K8sWaitLoadBalancer)
+	tools.AddReadTool(srv, "k8s_ingress_routes", "Summarize ingress host/path to service routes", tools.K8sIngressRoutes)
+	tools.AddReadTool(srv, "k8s_storage", "PVC binding status (and optionally PVs), with events for pending claims", tools.K8sStorage)
+	tools.AddReadTool(srv, "k8s_cert_expiry", "Expiry, subject and issuer of certificates in TLS secrets (and optionally the API server)", tools.K8sCertExpiry)
+	tools.AddReadTool(srv, "k8s_references", "List workloads and pods that use a ConfigMap or Secret (env, envFrom, volumes, imagePullSecrets)", tools.K8sReferences)
+	tools.AddReadTool(srv, "k8s_pods_using_image", "Find pods whose containers run an image (exact, repo or prefix match)", tools.K8sPodsUsingImage)
+	tools.AddReadTool(srv, "k8s_container_env", "Show a container's resolved environment (env/envFrom with ConfigMap, Secret and downward-API references resolved)", tools.K8sContainerEnv)
+	tools.AddReadTool(srv, "k8s_node_pods", "List pods scheduled on a node", tools.K8sNodePods)
+	tools.AddReadTool(srv, "k8s_node_pressure", "Report a node's pressure conditions, allocatable vs requested/used, and the pods most likely to be evicted", tools.K8sNodePressure)
+	tools.AddReadTool(srv, "k8s_top_nodes", "Top nodes", tools.K8sTopNodes)
+	tools.AddReadTool(srv, "k8s_top_pods", "Top pods", tools.K8sTopPods)
+	tools.AddReadTool(srv, "k8s_rightsize", "Compare container usage with requests/limits and flag over/under-provisioning", tools.K8sRightsize)
+	tools.AddReadTool(srv, "k8s_describe", "Describe Kubernetes resources", tools.K8sDescribe)
 	tools.AddTool(srv, "k8s_logs", "Get logs", tools.K8sLogs)
+	tools.AddReadTool(srv, "k8s_deployment_logs", "Logs of a deployment's current pods, all replicas or one by replica_index", tools.K8sDeploymentLogs)
+	tools.AddReadTool(srv, "k8s_service_logs", "Aggregated logs of the pods behind a service", tools.K8sServiceLogs)
+	tools.AddReadTool(srv, "k8s_why_restarting", "Diagnose why a pod's containers restart", tools.K8sWhyRestarting)
+	tools.AddReadTool(srv, "k8s_diagnose", "Diagnose a failing deployment: rollout, ReplicaSets, pods, events, PDBs and the likely problem", tools.K8sDiagnose)
+	tools.AddReadTool(srv, "k8s_restart_history", "A container's previous log plus its retained termination history and restart events", tools.K8sRestartHistory)
+	tools.AddTool(srv, "k8s_job_result", "Wait for a Job (or a CronJob's latest Job) to finish and return its status and pod logs", tools.K8sJobResult)
 	tools.AddTool(srv, "k8s_events", "Get events", tools.K8sEvents)
-	tools.AddTool(srv, "k8s_auth_can_i", "Auth can-i", tools.K8sAuthCanI)
-	tools.AddTool(srv, "k8s_auth_whoami", "Auth whoami", tools.K8sAuthWhoAmI)
+	tools.AddTool(srv, "k8s_object_events_watch", "Watch the events of one object (current ones, then new ones until timeout)", tools.K8sObjectEventsWatch)
+	tools.AddReadTool(srv, "k8s_auth_can_i", "Auth can-i", tools.K8sAuthCanI)
+	tools.AddReadTool(srv, "k8s_auth_can_i_batch", "Run several can-i checks at once", tools.K8sAuthCanIBatch)
+	tools.AddReadTool(srv, "k8s_auth_my_rules", "List what the caller can do in a namespace, grouped by verb", tools.K8sAuthMyRules)
+	tools.AddReadTool(srv, "k8s_rbac_for", "Show RBAC bindings and rules for a user, group or service account", tools.K8sRbacFor)
+	tools.AddReadTool(srv, "k8s_auth_whoami", "Auth whoami", tools.K8sAuthWhoAmI)
 }
 
-func registerWriteTools(srv *mcp.Server) {
+// registerWriteTools registers the mutating tools. The exec, file copy and
+// port-forward tools reach into containers and can each be turned off on
+// their own (--disable-exec, --disable-cp, --disable-portforward).
+func registerWriteTools(srv *mcp.Server, opts Options) {
 	tools.AddTool(srv, "k8s_create", "Create resources", tools.K8sCreate)
+	tools.AddTool(srv, "k8s_create_secret", "Create an Opaque, docker-registry or TLS secret from plain values", tools.K8sCreateSecret)
+	tools.AddTool(srv, "k8s_create_configmap", "Create (or update) a ConfigMap from key/value and binary data", tools.K8sCreateConfigMap)
 	tools.AddTool(srv, "k8s_expose", "Expose resources", tools.K8sExpose)
 	tools.AddTool(srv, "k8s_run", "Run resources", tools.K8sRun)
 	tools.AddTool(srv, "k8s_set_resources", "Set resources", tools.K8sSetResources)
 	tools.AddTool(srv, "k8s_set_image", "Set image", tools.K8sSetImage)
 	tools.AddTool(srv, "k8s_set_env", "Set env", tools.K8sSetEnv)
+	tools.AddTool(srv, "k8s_set_volume", "Add or remove a volume and its container volumeMount (configmap, secret, emptydir, pvc)", tools.K8sSetVolume)
+	tools.AddTool(srv, "k8s_set_probe", "Set or remove a container's liveness/readiness/startup probe", tools.K8sSetProbe)
+	tools.AddTool(srv, "k8s_set_command", "Set or clear a container's command and args", tools.K8sSetCommand)
 
 	tools.AddTool(srv, "k8s_rollout_undo", "Rollout undo", tools.K8sRolloutUndo)
 	tools.AddTool(srv, "k8s_rollout_restart", "Rollout restart", tools.K8sRolloutRestart)
 	tools.AddTool(srv, "k8s_rollout_pause", "Rollout pause", tools.K8sRolloutPause)
 	tools.AddTool(srv, "k8s_rollout_resume", "Rollout resume", tools.K8sRolloutResume)
+	tools.AddTool(srv, "k8s_suspend", "Suspend a CronJob or Job, or pause a Deployment", tools.K8sSuspend)
+	tools.AddTool(srv, "k8s_resume", "Resume a suspended CronJob or Job, or a paused Deployment", tools.K8sResume)
+	tools.AddTool(srv, "k8s_trigger_cronjob", "Create a Job from a CronJob now (like kubectl create job --from=cronjob/...)", tools.K8sTriggerCronJob)
 
 	tools.AddTool(srv, "k8s_scale", "Scale resources", tools.K8sScale)
+	tools.AddTool(srv, "k8s_scale_zero", "Scale matching deployments/statefulsets to zero, saving their replica counts", tools.K8sScaleZero)
+	tools.AddTool(srv, "k8s_scale_restore", "Restore workloads scaled down by k8s_scale_zero", tools.K8sScaleRestore)
 	tools.AddTool(srv, "k8s_autoscale", "Autoscale resources", tools.K8sAutoscale)
 	tools.AddTool(srv, "k8s_cordon", "Cordon node", tools.K8sCordon)
 	tools.AddTool(srv, "k8s_uncordon", "Uncordon node", tools.K8sUncordon)
+	tools.AddTool(srv, "k8s_cordon_selector", "Cordon all nodes matching a label selector", tools.K8sCordonSelector)
+	tools.AddTool(srv, "k8s_uncordon_selector", "Uncordon all nodes matching a label selector", tools.K8sUncordonSelector)
 	tools.AddTool(srv, "k8s_drain", "Drain node", tools.K8sDrain)
 
 	tools.AddTool(srv, "k8s_taint", "Taint node", tools.K8sTaint)
 	tools.AddTool(srv, "k8s_untaint", "Untaint node", tools.K8sUntaint)
 
-	tools.AddTool(srv, "k8s_exec_command", "Exec command", tools.K8sExecCommand)
-	tools.AddTool(srv, "k8s_port_forward", "Port-forward", tools.K8sPortForward)
-	tools.AddTool(srv, "k8s_cp", "Copy files", tools.K8sCp)
+	// Everything that runs commands in containers, including the file tools
+	// (tar/cat/head over pods/exec) and the debug tools.
+	if !opts.DisableExec {
+		tools.AddTool(srv, "k8s_exec_command", "Run a command in a pod container (output capped by max_bytes/max_lines)", tools.K8sExecCommand)
+		tools.AddTool(srv, "k8s_exec_workload", "Run a command in a ready pod of a deployment, statefulset or daemonset (reports the pod used)", tools.K8sExecWorkload)
+		tools.AddTool(srv, "k8s_debug", "Add an ephemeral debug container to a running pod", tools.K8sDebug)
+		tools.AddTool(srv, "k8s_copy_pod", "Create a standalone debug copy of a pod with optional image/command overrides", tools.K8sCopyPod)
+		if !opts.DisableCp {
+			tools.AddTool(srv, "k8s_cp", "Copy files", tools.K8sCp)
+			// Reads arbitrary paths over pods/exec, so it is not a read-only tool.
+			tools.AddReadTool(srv, "k8s_read_file", "Read a file from a container (text, or base64 for binary data)", tools.K8sReadFile)
+			tools.AddTool(srv, "k8s_write_file", "Write content to a file in a container", tools.K8sWriteFile)
+		}
+	}
+	if !opts.DisablePortForward {
+		tools.AddTool(srv, "k8s_port_forward", "Port-forward", tools.K8sPortForward)
+	}
 
 	tools.AddTool(srv, "k8s_apply", "Apply manifests", tools.K8sApply)
 	tools.AddTool(srv, "k8s_patch", "Patch resources", tools.K8sPatch)
-	tools.AddTool(srv, "k8s_label", "Label resources", tools.K8sLabel)
-	tools.AddTool(srv, "k8s_annotate", "Annotate resources", tools.K8sAnnotate)
+	tools.AddTool(srv, "k8s_patch_status", "Patch the status subresource", tools.K8sPatchStatus)
+	tools.AddTool(srv, "k8s_label", "Label resources by name or selector (supports dry_run)", tools.K8sLabel)
+	tools.AddTool(srv, "k8s_annotate", "Annotate resources by name or selector (supports dry_run)", tools.K8sAnnotate)
+	tools.AddTool(srv, "k8s_touch", "Set a timestamp annotation on an object to make its controller reconcile", tools.K8sTouch)
 }
 
 func registerDeleteTools(srv *mcp.Server) {
-	tools.AddTool(srv, "k8s_delete", "Delete resources", tools.K8sDelete)
+	tools.AddTool(srv, "k8s_delete", "Delete resources by name or selector (protected kinds need confirm=true)", tools.K8sDelete)
+	tools.AddTool(srv, "k8s_cleanup_pods", "Delete completed (Succeeded/Failed) pods, with dry_run preview", tools.K8sCleanupPods)
+	tools.AddTool(srv, "k8s_restart_pod", "Delete a controller-owned pod so it is recreated", tools.K8sRestartPod)
 }
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+)
+
+// serverTLSConfig builds the TLS settings of the HTTP transport from
+// --tls-cert, --tls-key and --tls-client-ca. It returns nil when TLS is off.
+// With a client CA, clients must present a certificate signed by it.
+func serverTLSConfig(opts Options) (*tls.Config, error) {
+	if opts.TLSCert == "" && opts.TLSKey == "" {
+		if opts.TLSClientCA != "" {
+			return nil, errors.New("--tls-client-ca requires --tls-cert and --tls-key")
+		}
+		return nil, nil
+	}
+	if opts.TLSCert == "" || opts.TLSKey == "" {
+		return nil, errors.New("--tls-cert and --tls-key must be given together")
+	}
+	// Fail at startup rather than on the first handshake.
+	if _, err := tls.LoadX509KeyPair(opts.TLSCert, opts.TLSKey); err != nil {
+		return nil, fmt.Errorf("load TLS key pair: %w", err)
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if opts.TLSClientCA != "" {
+		pem, err := os.ReadFile(opts.TLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("client CA %s holds no PEM certificate", opts.TLSClientCA)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// isLoopbackHost reports whether host (a --host value) only accepts
+// connections from this machine. An empty host binds every interface.
+func isLoopbackHost(host string) bool {
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// checkExposure runs before the HTTP transport listens. The server has no
+// authentication of its own, so whoever reaches the port gets its cluster
+// access. Plaintext on a non-loopback host is refused unless --insecure is
+// given, and then logged loudly; TLS without --tls-client-ca is allowed but
+// warned about, since it encrypts without checking who connects.
+func checkExposure(opts Options, tlsConfig *tls.Config, addr string) error {
+	if isLoopbackHost(opts.Host) {
+		return nil
+	}
+	if tlsConfig == nil {
+		if !opts.Insecure {
+			return fmt.Errorf("refusing to serve plaintext HTTP on non-loopback address %s: anyone who can reach it gets this server's cluster access; use --tls-cert/--tls-key (and --tls-client-ca), bind 127.0.0.1, or pass --insecure", addr)
+		}
+		log.Printf("WARNING: --insecure: serving unauthenticated, plaintext HTTP on %s; anyone who can reach it gets this server's cluster access", addr)
+		return nil
+	}
+	if tlsConfig.ClientCAs == nil {
+		log.Printf("warning: serving HTTPS on %s without --tls-client-ca; clients are not authenticated", addr)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var apiServiceGVR = schema.GroupVersionResource{Group: "apiregistration.k8s.io", Version: "v1", Resource: "apiservices"}
+
+type apiServiceRow struct {
+	Name           string `json:"name"`
+	Service        string `json:"service"` // namespace/name, or "Local" for built-in groups
+	Available      string `json:"available"`
+	Reason         string `json:"reason,omitempty"`
+	Message        string `json:"message,omitempty"`
+	LastTransition string `json:"last_transition,omitempty"`
+}
+
+// K8sAPIServices lists the APIServices of the aggregation layer with their
+// Available condition, unavailable ones first. An unavailable aggregated API
+// (metrics-server down, a removed webhook backend) makes discovery of its
+// group fail, which shows up as confusing errors in get, describe or top.
+// Read through the dynamic client: no apiregistration clientset is wired.
+//
+// Args:
+// - unavailable_only (bool): leave out APIServices that are Available
+func K8sAPIServices(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	unavailableOnly := boolFromArgs(args, "unavailable_only", false)
+
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	list, err := dyn.Resource(apiServiceGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	rows := []apiServiceRow{}
+	unavailable := 0
+	for i := range list.Items {
+		row := apiServiceRowFor(&list.Items[i])
+		if row.Available != "True" {
+			unavailable++
+		} else if unavailableOnly {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if (rows[i].Available == "True") != (rows[j].Available == "True") {
+			return rows[j].Available == "True"
+		}
+		return rows[i].Name < rows[j].Name
+	})
+
+	b, _ := json.MarshalIndent(map[string]any{
+		"total":       len(list.Items),
+		"unavailable": unavailable,
+		"apiservices": rows,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// apiServiceRowFor summarises one APIService. A missing Available condition
+// is reported as "Unknown".
+func apiServiceRowFor(u *unstructured.Unstructured) apiServiceRow {
+	row := apiServiceRow{Name: u.GetName(), Service: "Local", Available: "Unknown"}
+	if ns, _, _ := unstructured.NestedString(u.Object, "spec", "service", "namespace"); ns != "" {
+		name, _, _ := unstructured.NestedString(u.Object, "spec", "service", "name")
+		row.Service = ns + "/" + name
+	}
+	conds, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conds {
+		m, ok := c.(map[string]any)
+		if !ok || fmtAny(m["type"]) != "Available" {
+			continue
+		}
+		row.Available = fmtAny(m["status"])
+		row.Reason = fmtAny(m["reason"])
+		row.Message = fmtAny(m["message"])
+		row.LastTransition = fmtAny(m["lastTransitionTime"])
+	}
+	return row
+}
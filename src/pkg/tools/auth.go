@@ -4,12 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	authv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
 // K8sAuthWhoami mirrors auth.py k8s_auth_whoami():
@@ -18,13 +19,7 @@ import (
 func K8sAuthWhoami(ctx context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
 	_ = ctx
 
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-	if envKube := os.Getenv("KUBECONFIG"); envKube != "" {
-		loadingRules.ExplicitPath = envKube
-	}
-	overrides := &clientcmd.ConfigOverrides{}
-
-	raw, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).RawConfig()
+	raw, err := rawKubeconfig()
 	if err != nil {
 		return textErrorResult("Error:\n" + err.Error()), nil, nil
 	}
@@ -89,7 +84,7 @@ func K8sAuthCanI(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 
 	// Python default
 	if namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	cs, err := getClient()
@@ -121,6 +116,178 @@ func K8sAuthCanI(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 	return textOKResult(string(b)), nil, nil
 }
 
+// maxCanIBatch bounds the number of checks in one k8s_auth_can_i_batch call.
+const maxCanIBatch = 100
+
+type canICheck struct {
+	Verb        string `json:"verb"`
+	Resource    string `json:"resource"`
+	Subresource string `json:"subresource,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Allowed     bool   `json:"allowed"`
+	Reason      string `json:"reason,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// K8sAuthCanIBatch runs several can-i checks in one call, one
+// SelfSubjectAccessReview per entry, issued concurrently.
+//
+// Args: checks (required) list of {verb, resource, subresource, namespace, name};
+// namespace defaults to defaultNamespace() per entry, like k8s_auth_can_i.
+func K8sAuthCanIBatch(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	raw, _ := args["checks"].([]any)
+	if len(raw) == 0 {
+		return textErrorResult("checks is required (list of {verb, resource, ...})"), nil, nil
+	}
+	if len(raw) > maxCanIBatch {
+		return textErrorResult(fmt.Sprintf("Error: at most %d checks per call, got %d", maxCanIBatch, len(raw))), nil, nil
+	}
+
+	checks := make([]canICheck, len(raw))
+	for i, r := range raw {
+		m, ok := r.(map[string]any)
+		if !ok {
+			return textErrorResult(fmt.Sprintf("Error: checks[%d] must be an object", i)), nil, nil
+		}
+		c := canICheck{
+			Verb:        getStringArg(m, "verb"),
+			Resource:    getStringArg(m, "resource"),
+			Subresource: getStringArg(m, "subresource"),
+			Namespace:   getStringArg(m, "namespace"),
+			Name:        getStringArg(m, "name"),
+		}
+		if c.Verb == "" || c.Resource == "" {
+			return textErrorResult(fmt.Sprintf("Error: checks[%d]: verb and resource are required", i)), nil, nil
+		}
+		if c.Namespace == "" {
+			c.Namespace = defaultNamespace(ctx)
+		}
+		checks[i] = c
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	var wg sync.WaitGroup
+	tokens := make(chan struct{}, getAllConcurrency)
+	for i := range checks {
+		wg.Add(1)
+		go func(c *canICheck) {
+			defer wg.Done()
+			tokens <- struct{}{}
+			defer func() { <-tokens }()
+
+			sar := &authv1.SelfSubjectAccessReview{
+				Spec: authv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authv1.ResourceAttributes{
+						Namespace:   c.Namespace,
+						Verb:        c.Verb,
+						Resource:    c.Resource,
+						Subresource: c.Subresource,
+						Name:        c.Name,
+					},
+				},
+			}
+			resp, err := cs.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+			if err != nil {
+				c.Error = err.Error()
+				return
+			}
+			c.Allowed = resp.Status.Allowed
+			c.Reason = resp.Status.Reason
+		}(&checks[i])
+	}
+	wg.Wait()
+
+	b, _ := json.MarshalIndent(checks, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// K8sAuthMyRules lists everything the caller may do in a namespace using a
+// SelfSubjectRulesReview, grouped by verb so the result stays compact:
+// - resource entries are "resource[.group]", with "[name,...]" when restricted to names
+// - incomplete is set when the authorizer cannot enumerate all rules (e.g. webhooks)
+//
+// Args: namespace defaults to defaultNamespace()
+func K8sAuthMyRules(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	namespace, _ := args["namespace"].(string)
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	ssrr := &authv1.SelfSubjectRulesReview{
+		Spec: authv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+	}
+	resp, err := cs.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, ssrr, metav1.CreateOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	resourceRules := map[string][]string{}
+	for _, r := range resp.Status.ResourceRules {
+		groups := r.APIGroups
+		if len(groups) == 0 {
+			groups = []string{""}
+		}
+		for _, g := range groups {
+			for _, res := range r.Resources {
+				entry := res
+				if g != "" {
+					entry += "." + g
+				}
+				if len(r.ResourceNames) > 0 {
+					entry += " [" + strings.Join(r.ResourceNames, ",") + "]"
+				}
+				for _, v := range r.Verbs {
+					resourceRules[v] = appendUnique(resourceRules[v], entry)
+				}
+			}
+		}
+	}
+
+	nonResourceRules := map[string][]string{}
+	for _, r := range resp.Status.NonResourceRules {
+		for _, v := range r.Verbs {
+			for _, u := range r.NonResourceURLs {
+				nonResourceRules[v] = appendUnique(nonResourceRules[v], u)
+			}
+		}
+	}
+
+	for _, m := range []map[string][]string{resourceRules, nonResourceRules} {
+		for v := range m {
+			sort.Strings(m[v])
+		}
+	}
+
+	out := map[string]any{
+		"namespace":          namespace,
+		"incomplete":         resp.Status.Incomplete,
+		"resource_rules":     resourceRules,
+		"non_resource_rules": nonResourceRules,
+	}
+	if resp.Status.EvaluationError != "" {
+		out["evaluation_error"] = resp.Status.EvaluationError
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+func appendUnique(list []string, s string) []string {
+	if stringInSlice(s, list) {
+		return list
+	}
+	return append(list, s)
+}
+
 func emptyToNilString(s string) string {
 	// In k8s Go types, empty string is fine; this helper just keeps intent explicit.
 	return s
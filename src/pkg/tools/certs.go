@@ -0,0 +1,210 @@
+package tools
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type certExpiryRow struct {
+	Namespace string   `json:"namespace,omitempty"`
+	Secret    string   `json:"secret,omitempty"`
+	Endpoint  string   `json:"endpoint,omitempty"`
+	Subject   string   `json:"subject,omitempty"`
+	Issuer    string   `json:"issuer,omitempty"`
+	DNSNames  []string `json:"dns_names,omitempty"`
+	NotBefore string   `json:"not_before,omitempty"`
+	NotAfter  string   `json:"not_after,omitempty"`
+	DaysLeft  int      `json:"days_left"`
+	Status    string   `json:"status"` // ok, expiring, expired or error
+	Note      string   `json:"note,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// K8sCertExpiry reports when the certificates in kubernetes.io/tls Secrets
+// expire: subject, issuer, DNS names and notAfter of each tls.crt, soonest
+// first. Only the public certificate is parsed; tls.key and other values are
+// never read into the output. If an intermediate in the chain expires before
+// the leaf, that earlier date counts.
+//
+// Args:
+// - namespace defaults to defaultNamespace(); all_namespaces (bool)
+// - warn_days: certificates expiring within this many days are "expiring" (default 30)
+// - include_apiserver (bool): also check the serving certificate of the API server endpoint
+func K8sCertExpiry(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	namespace := getStringArg(args, "namespace")
+	allNamespaces := boolFromArgs(args, "all_namespaces", false)
+	warnDays := intFromArgsDefault(args, "warn_days", 30)
+	includeAPIServer := boolFromArgs(args, "include_apiserver", false)
+
+	if allNamespaces {
+		namespace = metav1.NamespaceAll
+	} else if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	secrets, err := cs.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: "type=" + string(v1.SecretTypeTLS),
+	})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	now := time.Now()
+	rows := []certExpiryRow{}
+	for _, s := range secrets.Items {
+		row := certExpiryRow{Namespace: s.Namespace, Secret: s.Name}
+		chain, err := parseCertChain(s.Data[v1.TLSCertKey])
+		if err != nil {
+			row.Status = "error"
+			row.Error = err.Error()
+		} else {
+			fillCertExpiry(&row, chain, now, warnDays)
+		}
+		rows = append(rows, row)
+	}
+	if includeAPIServer {
+		rows = append(rows, apiServerCertExpiry(ctx, now, warnDays))
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if (rows[i].Status == "error") != (rows[j].Status == "error") {
+			return rows[j].Status == "error"
+		}
+		return rows[i].DaysLeft < rows[j].DaysLeft
+	})
+	counts := map[string]int{}
+	for _, r := range rows {
+		counts[r.Status]++
+	}
+
+	b, _ := json.MarshalIndent(map[string]any{
+		"warn_days":    warnDays,
+		"counts":       counts,
+		"certificates": rows,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// parseCertChain decodes every CERTIFICATE block of a PEM bundle, leaf first.
+func parseCertChain(data []byte) ([]*x509.Certificate, error) {
+	if len(data) == 0 {
+		return nil, errors.New("no " + v1.TLSCertKey + " in secret")
+	}
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate: %v", err)
+		}
+		chain = append(chain, c)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New(v1.TLSCertKey + " holds no PEM certificate")
+	}
+	return chain, nil
+}
+
+// fillCertExpiry describes the leaf of chain; the expiry is the earliest
+// notAfter in the chain.
+func fillCertExpiry(row *certExpiryRow, chain []*x509.Certificate, now time.Time, warnDays int) {
+	leaf := chain[0]
+	row.Subject = leaf.Subject.String()
+	row.Issuer = leaf.Issuer.String()
+	row.DNSNames = leaf.DNSNames
+	row.NotBefore = leaf.NotBefore.UTC().Format(time.RFC3339)
+
+	notAfter := leaf.NotAfter
+	for _, c := range chain[1:] {
+		if c.NotAfter.Before(notAfter) {
+			notAfter = c.NotAfter
+			row.Note = fmt.Sprintf("chain certificate %q expires before the leaf", c.Subject.String())
+		}
+	}
+	row.NotAfter = notAfter.UTC().Format(time.RFC3339)
+	row.DaysLeft = int(math.Floor(notAfter.Sub(now).Hours() / 24))
+	switch {
+	case !now.Before(notAfter):
+		row.Status = "expired"
+	case row.DaysLeft < warnDays:
+		row.Status = "expiring"
+	default:
+		row.Status = "ok"
+	}
+}
+
+// apiServerCertExpiry reads the certificate the API server endpoint presents.
+// The handshake skips verification on purpose: the point is to inspect the
+// certificate, including an expired or untrusted one.
+func apiServerCertExpiry(ctx context.Context, now time.Time, warnDays int) certExpiryRow {
+	row := certExpiryRow{Status: "error"}
+	cfg, err := getRestConfig()
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	u, err := url.Parse(cfg.Host)
+	if err != nil || u.Host == "" {
+		row.Endpoint = cfg.Host
+		row.Error = "cannot parse API server host"
+		return row
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "443")
+	}
+	row.Endpoint = host
+	if u.Scheme != "https" {
+		row.Error = "API server endpoint is not https"
+		return row
+	}
+
+	dctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	dialer := &tls.Dialer{Config: &tls.Config{
+		ServerName:         strings.TrimSpace(cfg.TLSClientConfig.ServerName),
+		InsecureSkipVerify: true, // inspection only; no request is sent
+	}}
+	if dialer.Config.ServerName == "" {
+		dialer.Config.ServerName = u.Hostname()
+	}
+	conn, err := dialer.DialContext(dctx, "tcp", host)
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	defer conn.Close()
+	chain := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(chain) == 0 {
+		row.Error = "API server presented no certificate"
+		return row
+	}
+	fillCertExpiry(&row, chain, now, warnDays)
+	return row
+}
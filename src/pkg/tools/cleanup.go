@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type cleanupPodResult struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Phase      string `json:"phase"`
+	FinishedAt string `json:"finished_at,omitempty"`
+	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
+}
+
+// K8sCleanupPods deletes completed pods (Succeeded/Failed), the usual leftovers
+// of Job-heavy namespaces.
+//
+// Args:
+// - namespace defaults to defaultNamespace(); all_namespaces (bool)
+// - phases: list or comma-separated, subset of Succeeded,Failed (default both)
+// - older_than: e.g. "1h", "7d"; only pods finished at least that long ago
+// - selector: optional label selector
+// - dry_run (bool): list what would be deleted without deleting
+func K8sCleanupPods(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	namespace, _ := args["namespace"].(string)
+	allNamespaces := boolFromArgs(args, "all_namespaces", false)
+	selector := getStringArg(args, "selector")
+	dryRun := boolFromArgs(args, "dry_run", false)
+	if allNamespaces {
+		namespace = metav1.NamespaceAll
+	} else if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	phases := map[v1.PodPhase]bool{v1.PodSucceeded: true, v1.PodFailed: true}
+	if requested := stringSliceFromArgs(args, "phases"); len(requested) > 0 {
+		phases = map[v1.PodPhase]bool{}
+		for _, p := range requested {
+			switch strings.ToLower(p) {
+			case "succeeded":
+				phases[v1.PodSucceeded] = true
+			case "failed":
+				phases[v1.PodFailed] = true
+			default:
+				return textErrorResult(fmt.Sprintf("Error: unsupported phase '%s' (only Succeeded and Failed pods can be cleaned up)", p)), nil, nil
+			}
+		}
+	}
+
+	var minAge time.Duration
+	if olderThan := getStringArg(args, "older_than"); olderThan != "" {
+		if !sinceRe.MatchString(strings.TrimSpace(olderThan)) {
+			return textErrorResult(fmt.Sprintf("Error: invalid older_than %q (expected e.g. 30m, 2h, 7d)", olderThan)), nil, nil
+		}
+		minAge = time.Duration(*parseSinceSeconds(olderThan)) * time.Second
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	pods, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	now := time.Now()
+	results := []cleanupPodResult{}
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if !isCompletedPod(p) || !phases[p.Status.Phase] || p.DeletionTimestamp != nil {
+			continue
+		}
+		finished := podFinishedAt(p)
+		if minAge > 0 && now.Sub(finished) < minAge {
+			continue
+		}
+
+		r := cleanupPodResult{
+			Namespace:  p.Namespace,
+			Name:       p.Name,
+			Phase:      string(p.Status.Phase),
+			FinishedAt: finished.UTC().Format(time.RFC3339),
+			Status:     "deleted",
+		}
+		if dryRun {
+			r.Status = "would delete"
+		} else if err := cs.CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, metav1.DeleteOptions{}); err != nil {
+			r.Status = "error"
+			r.Message = formatK8sErr(err)
+		}
+		results = append(results, r)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Namespace != results[j].Namespace {
+			return results[i].Namespace < results[j].Namespace
+		}
+		return results[i].Name < results[j].Name
+	})
+
+	b, _ := json.MarshalIndent(map[string]any{
+		"dry_run": dryRun,
+		"count":   len(results),
+		"pods":    results,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// podFinishedAt is the latest container termination time, falling back to the
+// pod's start or creation time when no container reports one.
+func podFinishedAt(p *v1.Pod) time.Time {
+	var latest time.Time
+	for _, st := range p.Status.ContainerStatuses {
+		if t := st.State.Terminated; t != nil && t.FinishedAt.After(latest) {
+			latest = t.FinishedAt.Time
+		}
+	}
+	if !latest.IsZero() {
+		return latest
+	}
+	if p.Status.StartTime != nil {
+		return p.Status.StartTime.Time
+	}
+	return p.CreationTimestamp.Time
+}
@@ -2,10 +2,15 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	extclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -13,27 +18,59 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+// SetupClient failures are one of these, wrapped with the underlying cause, so
+// callers can tell a missing configuration from a cluster that is (still) down.
 var (
-	kubeClient      *kubernetes.Clientset
-	kubeConfig      *rest.Config
-	dynClient       dynamic.Interface
-	discClient      discovery.DiscoveryInterface
-	apiExtClientset *extclientset.Clientset
+	ErrNoKubeConfig       = errors.New("no usable Kubernetes configuration found")
+	ErrClusterUnreachable = errors.New("Kubernetes cluster is unreachable")
+)
+
+// setupPingTimeout bounds the health check so a dead API server can't stall a
+// tool call that is retrying setup.
+const setupPingTimeout = 10 * time.Second
+
+// clientBundle is one consistent set of clients built from the same rest.Config.
+// A bundle is immutable once published; re-initialization swaps in a new one.
+type clientBundle struct {
+	config           *rest.Config
+	clientset        *kubernetes.Clientset
+	dynamic          dynamic.Interface
+	discovery        discovery.DiscoveryInterface
+	apiExtensions    *extclientset.Clientset
+	contextNamespace string // namespace of the current kubeconfig context, if any
+}
+
+var (
+	// clients is read lock-free by every handler.
+	clients atomic.Pointer[clientBundle]
+	// setupMu serializes (re)initialization so concurrent handlers don't each
+	// build their own clients after a failed startup.
+	setupMu sync.Mutex
 )
 
 // SetupClient mirrors the Python setup_client():
 // - best-effort setupKubeconfig() to generate ~/.kube/config when running in a Pod
 // - try in-cluster config
 // - fall back to kubeconfig (KUBECONFIG or ~/.kube/config)
+//
+// Clients are only kept once a discovery ping succeeds; otherwise nothing is
+// cached and the next tool call tries again. Errors wrap ErrNoKubeConfig or
+// ErrClusterUnreachable.
 func SetupClient(ctx context.Context) error {
-	_ = ctx
+	setupMu.Lock()
+	defer setupMu.Unlock()
+	return setupClientLocked(ctx)
+}
 
+func setupClientLocked(ctx context.Context) error {
 	_ = setupKubeconfig()
 
-	if kubeClient != nil && kubeConfig != nil && dynClient != nil && discClient != nil && apiExtClientset != nil {
+	if clients.Load() != nil {
 		return nil
 	}
 
+	ns := ""
+
 	// 1) Try in-cluster
 	cfg, err := rest.InClusterConfig()
 	if err != nil {
@@ -43,75 +80,137 @@ func SetupClient(ctx context.Context) error {
 			loadingRules.ExplicitPath = envKube
 		}
 		overrides := &clientcmd.ConfigOverrides{}
-		cfg, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 			loadingRules,
 			overrides,
-		).ClientConfig()
+		)
+		cfg, err = clientConfig.ClientConfig()
 		if err != nil {
-			return fmt.Errorf("build Kubernetes client config: %w", err)
+			return fmt.Errorf("%w: build Kubernetes client config: %v", ErrNoKubeConfig, err)
+		}
+		// Namespace() reports "default" when the context sets none; only an
+		// explicit context namespace should override the built-in fallback.
+		if n, explicit, err := clientConfig.Namespace(); err == nil && explicit {
+			ns = n
 		}
 	}
 
-	cs, err := kubernetes.NewForConfig(cfg)
+	cs, err := kubernetes.NewForConfig(typedClientConfig(cfg))
 	if err != nil {
-		return fmt.Errorf("create Kubernetes clientset: %w", err)
+		return fmt.Errorf("%w: create Kubernetes clientset: %v", ErrNoKubeConfig, err)
 	}
 
 	dc, err := dynamic.NewForConfig(cfg)
 	if err != nil {
-		return fmt.Errorf("create Kubernetes dynamic client: %w", err)
+		return fmt.Errorf("%w: create Kubernetes dynamic client: %v", ErrNoKubeConfig, err)
 	}
 
 	disc, err := discovery.NewDiscoveryClientForConfig(cfg)
 	if err != nil {
-		return fmt.Errorf("create Kubernetes discovery client: %w", err)
+		return fmt.Errorf("%w: create Kubernetes discovery client: %v", ErrNoKubeConfig, err)
 	}
 
 	extcs, err := extclientset.NewForConfig(cfg)
 	if err != nil {
-		return fmt.Errorf("create Kubernetes apiextensions clientset: %w", err)
+		return fmt.Errorf("%w: create Kubernetes apiextensions clientset: %v", ErrNoKubeConfig, err)
+	}
+
+	// Health check: a cheap discovery call proves the API server answers.
+	if err := pingDiscovery(ctx, disc); err != nil {
+		return fmt.Errorf("%w: %v", ErrClusterUnreachable, err)
 	}
 
-	kubeConfig = cfg
-	kubeClient = cs
-	dynClient = dc
-	discClient = disc
-	apiExtClientset = extcs
+	clients.Store(&clientBundle{
+		config:           cfg,
+		clientset:        cs,
+		dynamic:          dc,
+		discovery:        disc,
+		apiExtensions:    extcs,
+		contextNamespace: ns,
+	})
+	resetRESTMapper()
 
 	return nil
 }
 
+// typedClientConfig is cfg for the typed clientset, switched to protobuf when
+// --protobuf is set. Only built-in types have protobuf encodings, so the
+// dynamic, discovery and apiextensions clients always keep cfg (JSON).
+func typedClientConfig(cfg *rest.Config) *rest.Config {
+	if !useProtobuf {
+		return cfg
+	}
+	c := rest.CopyConfig(cfg)
+	c.AcceptContentTypes = runtime.ContentTypeProtobuf + "," + runtime.ContentTypeJSON
+	c.ContentType = runtime.ContentTypeProtobuf
+	return c
+}
+
+// pingDiscovery GETs /version, bounded by ctx and setupPingTimeout.
+func pingDiscovery(ctx context.Context, disc *discovery.DiscoveryClient) error {
+	ctx, cancel := context.WithTimeout(ctx, setupPingTimeout)
+	defer cancel()
+	return disc.RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+}
+
+// currentClients returns the published bundle, retrying setup when an earlier
+// attempt left nothing behind.
+func currentClients() (*clientBundle, error) {
+	if b := clients.Load(); b != nil {
+		return b, nil
+	}
+	setupMu.Lock()
+	defer setupMu.Unlock()
+	if err := setupClientLocked(context.Background()); err != nil {
+		return nil, fmt.Errorf("Kubernetes client is not initialized: %w", err)
+	}
+	return clients.Load(), nil
+}
+
 func getClient() (*kubernetes.Clientset, error) {
-	if kubeClient == nil {
-		return nil, fmt.Errorf("Kubernetes client is not initialized")
+	b, err := currentClients()
+	if err != nil {
+		return nil, err
 	}
-	return kubeClient, nil
+	return b.clientset, nil
 }
 
 func getDiscovery() (discovery.DiscoveryInterface, error) {
-	if discClient == nil {
-		return nil, fmt.Errorf("Kubernetes discovery client is not initialized")
+	b, err := currentClients()
+	if err != nil {
+		return nil, err
 	}
-	return discClient, nil
+	return b.discovery, nil
 }
 
 func getDynamic() (dynamic.Interface, error) {
-	if dynClient == nil {
-		return nil, fmt.Errorf("Kubernetes dynamic client is not initialized")
+	b, err := currentClients()
+	if err != nil {
+		return nil, err
 	}
-	return dynClient, nil
+	return b.dynamic, nil
 }
 
 func getAPIExtensions() (*extclientset.Clientset, error) {
-	if apiExtClientset == nil {
-		return nil, fmt.Errorf("Kubernetes apiextensions clientset is not initialized")
+	b, err := currentClients()
+	if err != nil {
+		return nil, err
 	}
-	return apiExtClientset, nil
+	return b.apiExtensions, nil
 }
 
 func getRestConfig() (*rest.Config, error) {
-	if kubeConfig == nil {
-		return nil, fmt.Errorf("Kubernetes REST config is not initialized")
+	b, err := currentClients()
+	if err != nil {
+		return nil, err
+	}
+	return b.config, nil
+}
+
+// getContextNamespace does not trigger setup; it is "" until clients exist.
+func getContextNamespace() string {
+	if b := clients.Load(); b != nil {
+		return b.contextNamespace
 	}
-	return kubeConfig, nil
+	return ""
 }
@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+const benchPods = 2000
+
+func benchPodList(n int) *v1.PodList {
+	list := &v1.PodList{TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"}}
+	for i := 0; i < n; i++ {
+		list.Items = append(list.Items, v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("web-6d4cf56db6-%05d", i),
+				Namespace:         "bench",
+				UID:               types.UID(fmt.Sprintf("0b6f3e5c-3b7e-4a51-9d8a-%012d", i)),
+				Labels:            map[string]string{"app": "web", "pod-template-hash": "6d4cf56db6"},
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: v1.PodSpec{
+				NodeName: fmt.Sprintf("node-%d", i%50),
+				Containers: []v1.Container{{
+					Name:  "web",
+					Image: "registry.example.com/web:1.2.3",
+					Ports: []v1.ContainerPort{{ContainerPort: 8080, Protocol: v1.ProtocolTCP}},
+					Env:   []v1.EnvVar{{Name: "LOG_LEVEL", Value: "info"}},
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m"), v1.ResourceMemory: resource.MustParse("128Mi")},
+						Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("256Mi")},
+					},
+				}},
+			},
+			Status: v1.PodStatus{
+				Phase:  v1.PodRunning,
+				PodIP:  fmt.Sprintf("10.0.%d.%d", i/250, i%250),
+				HostIP: fmt.Sprintf("192.168.0.%d", i%50),
+				Conditions: []v1.PodCondition{
+					{Type: v1.PodReady, Status: v1.ConditionTrue},
+					{Type: v1.PodScheduled, Status: v1.ConditionTrue},
+				},
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name:  "web",
+					Ready: true,
+					Image: "registry.example.com/web:1.2.3",
+					State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: metav1.Now()}},
+				}},
+			},
+		})
+	}
+	return list
+}
+
+// fakePodsServer serves the same pod list as JSON or protobuf, whichever the
+// client's Accept header prefers.
+func fakePodsServer(b *testing.B, list *v1.PodList) *httptest.Server {
+	encode := func(mediaType string) []byte {
+		info, ok := runtime.SerializerInfoForMediaType(scheme.Codecs.SupportedMediaTypes(), mediaType)
+		if !ok {
+			b.Fatalf("no serializer for %s", mediaType)
+		}
+		data, err := runtime.Encode(scheme.Codecs.EncoderForVersion(info.Serializer, v1.SchemeGroupVersion), list)
+		if err != nil {
+			b.Fatal(err)
+		}
+		return data
+	}
+	jsonBody := encode(runtime.ContentTypeJSON)
+	protoBody := encode(runtime.ContentTypeProtobuf)
+	b.Logf("%d pods: json %d bytes, protobuf %d bytes", len(list.Items), len(jsonBody), len(protoBody))
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Accept"), runtime.ContentTypeProtobuf) {
+			w.Header().Set("Content-Type", runtime.ContentTypeProtobuf)
+			_, _ = w.Write(protoBody)
+			return
+		}
+		w.Header().Set("Content-Type", runtime.ContentTypeJSON)
+		_, _ = w.Write(jsonBody)
+	}))
+}
+
+// BenchmarkListPodsJSONvsProtobuf lists a large namespace through the typed
+// clientset built by typedClientConfig, with and without --protobuf.
+func BenchmarkListPodsJSONvsProtobuf(b *testing.B) {
+	srv := fakePodsServer(b, benchPodList(benchPods))
+	defer srv.Close()
+	defer SetUseProtobuf(useProtobuf)
+
+	for _, tc := range []struct {
+		name     string
+		protobuf bool
+	}{{"json", false}, {"protobuf", true}} {
+		b.Run(tc.name, func(b *testing.B) {
+			SetUseProtobuf(tc.protobuf)
+			cs, err := kubernetes.NewForConfig(typedClientConfig(&rest.Config{Host: srv.URL}))
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				list, err := cs.CoreV1().Pods("bench").List(context.Background(), metav1.ListOptions{})
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(list.Items) != benchPods {
+					b.Fatalf("got %d pods, want %d", len(list.Items), benchPods)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type healthCheck struct {
+	Name string `json:"name"`
+	OK   bool   `json:"ok"`
+}
+
+// K8sClusterInfo is the connectivity check: the API server version, the
+// control-plane endpoint this server talks to, and the API server's /readyz
+// checks (etcd, informers, ...). /healthz is used when /readyz is unavailable
+// (very old servers); componentstatuses is deprecated and not consulted.
+func K8sClusterInfo(ctx context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	rc, err := getRestConfig()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	v, err := disc.ServerVersion()
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	out := map[string]any{
+		"control_plane": rc.Host,
+		"server_version": map[string]any{
+			"git_version": v.GitVersion,
+			"major":       v.Major,
+			"minor":       v.Minor,
+			"platform":    v.Platform,
+			"go_version":  v.GoVersion,
+			"build_date":  v.BuildDate,
+		},
+	}
+
+	endpoint := "/readyz"
+	body, err := disc.RESTClient().Get().AbsPath(endpoint).Param("verbose", "true").DoRaw(ctx)
+	if apierrors.IsNotFound(err) {
+		endpoint = "/healthz"
+		body, err = disc.RESTClient().Get().AbsPath(endpoint).Param("verbose", "true").DoRaw(ctx)
+	}
+	health := map[string]any{"endpoint": endpoint}
+	checks, ready := parseHealthChecks(string(body))
+	if err != nil && len(checks) == 0 {
+		// A failing /readyz returns 500 with the check list as body; only a
+		// missing body means we couldn't ask at all.
+		health["error"] = formatK8sErr(err)
+	} else {
+		health["ready"] = ready && err == nil
+		health["checks"] = checks
+	}
+	out["health"] = health
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// parseHealthChecks reads the verbose health output ("[+]etcd ok",
+// "[-]etcd failed: reason withheld") and reports whether every check passed.
+func parseHealthChecks(body string) ([]healthCheck, bool) {
+	checks := []healthCheck{}
+	ready := true
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		var ok bool
+		switch {
+		case strings.HasPrefix(line, "[+]"):
+			ok = true
+		case strings.HasPrefix(line, "[-]"):
+			ok = false
+			ready = false
+		default:
+			continue
+		}
+		name, _, _ := strings.Cut(line[3:], " ")
+		checks = append(checks, healthCheck{Name: name, OK: ok})
+	}
+	return checks, ready
+}
@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// K8sCreateConfigMap builds a ConfigMap from key -> value maps, the companion of
+// k8s_create_secret.
+//
+// Args:
+// - name (required); namespace defaults to defaultNamespace()
+// - data: object of key -> UTF-8 string
+// - binary_data: object of key -> base64 content, for non-UTF-8 files
+// - update_if_exists (bool): replace data/binaryData of an existing ConfigMap instead of failing
+func K8sCreateConfigMap(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	name := getStringArg(args, "name")
+	namespace := getStringArg(args, "namespace")
+	updateIfExists := boolFromArgs(args, "update_if_exists", false)
+
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	data := map[string]string{}
+	if raw, ok := args["data"]; ok && raw != nil {
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return textErrorResult("Error: data must be an object of key -> value"), nil, nil
+		}
+		for k, v := range m {
+			s := fmtAny(v)
+			if !utf8.ValidString(s) {
+				return textErrorResult(fmt.Sprintf("Error: data['%s'] is not valid UTF-8; pass it base64-encoded in binary_data", k)), nil, nil
+			}
+			data[k] = s
+		}
+	}
+
+	binaryData := map[string][]byte{}
+	if raw, ok := args["binary_data"]; ok && raw != nil {
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return textErrorResult("Error: binary_data must be an object of key -> base64 value"), nil, nil
+		}
+		for k, v := range m {
+			s, _ := v.(string)
+			decoded, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return textErrorResult(fmt.Sprintf("Error: binary_data['%s'] is not valid base64: %v", k, err)), nil, nil
+			}
+			if _, dup := data[k]; dup {
+				return textErrorResult(fmt.Sprintf("Error: key '%s' is in both data and binary_data", k)), nil, nil
+			}
+			binaryData[k] = decoded
+		}
+	}
+
+	if len(data) == 0 && len(binaryData) == 0 {
+		return textErrorResult("data or binary_data is required"), nil, nil
+	}
+	if err := validateDataKeys(data); err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+	if err := validateDataKeys(binaryData); err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       data,
+		BinaryData: binaryData,
+	}
+	if len(binaryData) == 0 {
+		cm.BinaryData = nil
+	}
+
+	action := "created"
+	result, err := cs.CoreV1().ConfigMaps(namespace).Create(ctx, cm, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) && updateIfExists {
+		existing, getErr := cs.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if getErr != nil {
+			return textErrorResult(formatK8sErr(getErr)), nil, nil
+		}
+		if existing.Immutable != nil && *existing.Immutable {
+			return textErrorResult(fmt.Sprintf("Error: configmap '%s' is immutable; delete and recreate it instead", name)), nil, nil
+		}
+		existing.Data = cm.Data
+		existing.BinaryData = cm.BinaryData
+		action = "updated"
+		result, err = cs.CoreV1().ConfigMaps(namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	result.ManagedFields = nil
+	b, _ := json.MarshalIndent(map[string]any{
+		"action":    action,
+		"configmap": result,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
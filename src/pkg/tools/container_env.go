@@ -0,0 +1,388 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type envVarView struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	// Overrides is the source this entry replaced (a later env/envFrom entry wins).
+	Overrides string `json:"overrides,omitempty"`
+}
+
+// K8sContainerEnv shows a container's environment the way the kubelet builds
+// it: envFrom sources first (in order, with their prefix), then env entries,
+// later names overriding earlier ones. valueFrom references are resolved
+// (ConfigMap/Secret keys, downward-API fields, resource fields) and $(VAR)
+// references expanded. Values are read now, so a ConfigMap or Secret edited
+// since the container started may differ from what the process sees.
+//
+// Secret values are redacted unless reveal_secrets is true, which additionally
+// needs the server started with --allow-secret-reveal.
+//
+// Args:
+// - pod_name (required); namespace defaults to defaultNamespace()
+// - container: defaults to the pod's default container
+// - reveal_secrets (bool)
+func K8sContainerEnv(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	podName := getStringArg(args, "pod_name", "pod", "name")
+	namespace := getStringArg(args, "namespace")
+	containerName := getStringArg(args, "container")
+	reveal := boolFromArgs(args, "reveal_secrets", false)
+
+	if strings.TrimSpace(podName) == "" {
+		return textErrorResult("pod_name is required"), nil, nil
+	}
+	if reveal && !secretRevealAllowed {
+		return textErrorResult("Error: reveal_secrets requires the server to be started with --allow-secret-reveal"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	if containerName == "" {
+		containerName = podDefaultContainerName(pod)
+	}
+	var container *v1.Container
+	all := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for i := range all {
+		if all[i].Name == containerName {
+			container = &all[i]
+		}
+	}
+	if container == nil {
+		return textErrorResult(fmt.Sprintf("Error: container '%s' not found in pod %s/%s", containerName, namespace, podName)), nil, nil
+	}
+
+	r := &envResolver{ctx: ctx, cs: cs, pod: pod, container: container, reveal: reveal}
+	vars := r.resolve()
+
+	out := map[string]any{
+		"pod":       podName,
+		"namespace": namespace,
+		"container": containerName,
+		"env":       vars,
+	}
+	if !reveal {
+		out["secrets_redacted"] = true
+	}
+	if len(r.notes) > 0 {
+		out["notes"] = r.notes
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// envResolver caches the ConfigMaps and Secrets a container references.
+type envResolver struct {
+	ctx       context.Context
+	cs        *kubernetes.Clientset
+	pod       *v1.Pod
+	container *v1.Container
+	reveal    bool
+
+	configMaps map[string]*v1.ConfigMap
+	secrets    map[string]*v1.Secret
+	notes      []string
+}
+
+func (r *envResolver) resolve() []envVarView {
+	vars := []envVarView{}
+	index := map[string]int{}
+	values := map[string]string{}
+	set := func(v envVarView) {
+		if i, ok := index[v.Name]; ok {
+			v.Overrides = vars[i].Source
+			vars[i] = v
+		} else {
+			index[v.Name] = len(vars)
+			vars = append(vars, v)
+		}
+		values[v.Name] = v.Value
+	}
+
+	for _, from := range r.container.EnvFrom {
+		optional := false
+		switch {
+		case from.ConfigMapRef != nil:
+			if from.ConfigMapRef.Optional != nil {
+				optional = *from.ConfigMapRef.Optional
+			}
+			cm := r.configMap(from.ConfigMapRef.Name, optional)
+			if cm == nil {
+				continue
+			}
+			keys := make([]string, 0, len(cm.Data))
+			for k := range cm.Data {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				set(envVarView{Name: from.Prefix + k, Value: cm.Data[k], Source: "envFrom configmap/" + cm.Name})
+			}
+		case from.SecretRef != nil:
+			if from.SecretRef.Optional != nil {
+				optional = *from.SecretRef.Optional
+			}
+			s := r.secret(from.SecretRef.Name, optional)
+			if s == nil {
+				continue
+			}
+			keys := make([]string, 0, len(s.Data))
+			for k := range s.Data {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				set(envVarView{Name: from.Prefix + k, Value: r.secretValue(s.Data[k]), Source: "envFrom secret/" + s.Name})
+			}
+		}
+	}
+
+	for _, e := range r.container.Env {
+		if e.ValueFrom == nil {
+			set(envVarView{Name: e.Name, Value: expandEnvRefs(e.Value, values), Source: "env"})
+			continue
+		}
+		v, source, ok := r.valueFrom(e.Name, e.ValueFrom)
+		if ok {
+			set(envVarView{Name: e.Name, Value: v, Source: source})
+		}
+	}
+	return vars
+}
+
+func (r *envResolver) valueFrom(name string, from *v1.EnvVarSource) (string, string, bool) {
+	switch {
+	case from.ConfigMapKeyRef != nil:
+		ref := from.ConfigMapKeyRef
+		optional := ref.Optional != nil && *ref.Optional
+		source := fmt.Sprintf("configmap/%s key %s", ref.Name, ref.Key)
+		cm := r.configMap(ref.Name, optional)
+		if cm == nil {
+			return "", "", false
+		}
+		v, ok := cm.Data[ref.Key]
+		if !ok {
+			r.keyMissing(name, source, optional)
+			return "", "", false
+		}
+		return v, source, true
+	case from.SecretKeyRef != nil:
+		ref := from.SecretKeyRef
+		optional := ref.Optional != nil && *ref.Optional
+		source := fmt.Sprintf("secret/%s key %s", ref.Name, ref.Key)
+		s := r.secret(ref.Name, optional)
+		if s == nil {
+			return "", "", false
+		}
+		v, ok := s.Data[ref.Key]
+		if !ok {
+			r.keyMissing(name, source, optional)
+			return "", "", false
+		}
+		return r.secretValue(v), source, true
+	case from.FieldRef != nil:
+		path := from.FieldRef.FieldPath
+		v, ok := podFieldValue(r.pod, path)
+		if !ok {
+			r.notes = append(r.notes, fmt.Sprintf("%s: field %s is not resolved by this tool", name, path))
+			return "", "", false
+		}
+		return v, "fieldRef " + path, true
+	case from.ResourceFieldRef != nil:
+		ref := from.ResourceFieldRef
+		v, err := r.resourceFieldValue(ref)
+		if err != nil {
+			r.notes = append(r.notes, fmt.Sprintf("%s: %v", name, err))
+			return "", "", false
+		}
+		return v, "resourceFieldRef " + ref.Resource, true
+	}
+	return "", "", false
+}
+
+func (r *envResolver) configMap(name string, optional bool) *v1.ConfigMap {
+	if cm, ok := r.configMaps[name]; ok {
+		return cm
+	}
+	if r.configMaps == nil {
+		r.configMaps = map[string]*v1.ConfigMap{}
+	}
+	cm, err := r.cs.CoreV1().ConfigMaps(r.pod.Namespace).Get(r.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		r.refMissing("configmap/"+name, err, optional)
+		cm = nil
+	}
+	r.configMaps[name] = cm
+	return cm
+}
+
+func (r *envResolver) secret(name string, optional bool) *v1.Secret {
+	if s, ok := r.secrets[name]; ok {
+		return s
+	}
+	if r.secrets == nil {
+		r.secrets = map[string]*v1.Secret{}
+	}
+	s, err := r.cs.CoreV1().Secrets(r.pod.Namespace).Get(r.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		r.refMissing("secret/"+name, err, optional)
+		s = nil
+	}
+	r.secrets[name] = s
+	return s
+}
+
+func (r *envResolver) refMissing(ref string, err error, optional bool) {
+	switch {
+	case apierrors.IsNotFound(err) && optional:
+		r.notes = append(r.notes, ref+" not found (optional, skipped)")
+	case apierrors.IsNotFound(err):
+		r.notes = append(r.notes, ref+" not found; the container cannot start with this reference")
+	default:
+		r.notes = append(r.notes, ref+": "+formatK8sErr(err))
+	}
+}
+
+func (r *envResolver) keyMissing(name, source string, optional bool) {
+	if optional {
+		r.notes = append(r.notes, fmt.Sprintf("%s: %s not found (optional, skipped)", name, source))
+		return
+	}
+	r.notes = append(r.notes, fmt.Sprintf("%s: %s not found; the container cannot start with this reference", name, source))
+}
+
+func (r *envResolver) secretValue(v []byte) string {
+	if r.reveal {
+		return string(v)
+	}
+	return fmt.Sprintf("<redacted, %d bytes>", len(v))
+}
+
+// resourceFieldValue mirrors the downward API: the container's limit (or
+// request) divided by the divisor and rounded up.
+func (r *envResolver) resourceFieldValue(ref *v1.ResourceFieldSelector) (string, error) {
+	c := r.container
+	if ref.ContainerName != "" && ref.ContainerName != c.Name {
+		for i := range r.pod.Spec.Containers {
+			if r.pod.Spec.Containers[i].Name == ref.ContainerName {
+				c = &r.pod.Spec.Containers[i]
+			}
+		}
+	}
+	var q resource.Quantity
+	var ok bool
+	switch {
+	case strings.HasPrefix(ref.Resource, "limits."):
+		q, ok = c.Resources.Limits[v1.ResourceName(strings.TrimPrefix(ref.Resource, "limits."))]
+		if !ok {
+			return "", fmt.Errorf("%s is not set; the kubelet uses the node's allocatable", ref.Resource)
+		}
+	case strings.HasPrefix(ref.Resource, "requests."):
+		q, ok = c.Resources.Requests[v1.ResourceName(strings.TrimPrefix(ref.Resource, "requests."))]
+		if !ok {
+			return "0", nil
+		}
+	default:
+		return "", fmt.Errorf("unsupported resource %s", ref.Resource)
+	}
+	divisor := ref.Divisor
+	if divisor.IsZero() {
+		divisor = resource.MustParse("1")
+	}
+	if strings.HasSuffix(ref.Resource, ".cpu") {
+		return fmt.Sprintf("%d", (q.MilliValue()+divisor.MilliValue()-1)/divisor.MilliValue()), nil
+	}
+	return fmt.Sprintf("%d", (q.Value()+divisor.Value()-1)/divisor.Value()), nil
+}
+
+// podFieldValue resolves the downward-API field paths env vars may use.
+func podFieldValue(pod *v1.Pod, path string) (string, bool) {
+	if strings.HasPrefix(path, "metadata.labels['") && strings.HasSuffix(path, "']") {
+		return pod.Labels[strings.TrimSuffix(strings.TrimPrefix(path, "metadata.labels['"), "']")], true
+	}
+	if strings.HasPrefix(path, "metadata.annotations['") && strings.HasSuffix(path, "']") {
+		return pod.Annotations[strings.TrimSuffix(strings.TrimPrefix(path, "metadata.annotations['"), "']")], true
+	}
+	switch path {
+	case "metadata.name":
+		return pod.Name, true
+	case "metadata.namespace":
+		return pod.Namespace, true
+	case "metadata.uid":
+		return string(pod.UID), true
+	case "spec.nodeName":
+		return pod.Spec.NodeName, true
+	case "spec.serviceAccountName":
+		return pod.Spec.ServiceAccountName, true
+	case "status.hostIP":
+		return pod.Status.HostIP, true
+	case "status.podIP":
+		return pod.Status.PodIP, true
+	case "status.podIPs":
+		ips := make([]string, 0, len(pod.Status.PodIPs))
+		for _, ip := range pod.Status.PodIPs {
+			ips = append(ips, ip.IP)
+		}
+		return strings.Join(ips, ","), true
+	}
+	return "", false
+}
+
+// expandEnvRefs replaces $(VAR) with earlier variables the way the kubelet
+// does: unknown references stay as written and $$ escapes a $.
+func expandEnvRefs(s string, values map[string]string) string {
+	if !strings.Contains(s, "$") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch s[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '(':
+			end := strings.IndexByte(s[i+2:], ')')
+			if end < 0 {
+				b.WriteString(s[i:])
+				return b.String()
+			}
+			name := s[i+2 : i+2+end]
+			if v, ok := values[name]; ok {
+				b.WriteString(v)
+			} else {
+				b.WriteString(s[i : i+3+end])
+			}
+			i += 2 + end
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -26,7 +26,7 @@ func K8sCp(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*m
 	container, _ := args["container"].(string)
 	namespace, _ := args["namespace"].(string)
 	if namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	if strings.TrimSpace(srcPath) == "" {
@@ -277,10 +277,31 @@ func defaultContainer(ctx context.Context, cs *kubernetes.Clientset, namespace,
 	if err != nil {
 		return "", err
 	}
-	if len(pod.Spec.Containers) == 0 {
+	name := podDefaultContainerName(pod)
+	if name == "" {
 		return "", fmt.Errorf("No containers found in pod")
 	}
-	return pod.Spec.Containers[0].Name, nil
+	return name, nil
+}
+
+// defaultContainerAnnotation is honored by kubectl logs/exec/cp to pick the
+// container to target in multi-container pods.
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// podDefaultContainerName returns the container named by the default-container
+// annotation when it exists in the pod, else the first container ("" if none).
+func podDefaultContainerName(pod *corev1.Pod) string {
+	if want := pod.Annotations[defaultContainerAnnotation]; want != "" {
+		for _, c := range pod.Spec.Containers {
+			if c.Name == want {
+				return want
+			}
+		}
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return ""
+	}
+	return pod.Spec.Containers[0].Name
 }
 
 func podPathIsDir(ctx context.Context, cs *kubernetes.Clientset, rc *rest.Config, namespace, pod, container, podPath string) (bool, error) {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -25,55 +26,151 @@ type createResult struct {
 	Object  map[string]any `json:"object,omitempty"`
 	Result  map[string]any `json:"result,omitempty"`
 	GVR     string         `json:"gvr,omitempty"`
+
+	// Webhook names the admission webhook that rejected the object, if any.
+	Webhook *webhookInfo `json:"webhook,omitempty"`
+
+	// Ownership summarizes managedFields after a server-side apply.
+	Ownership *fieldOwnership `json:"ownership,omitempty"`
+
+	// bookkeeping for prune; not part of the output
+	gvr        schema.GroupVersionResource
+	namespaced bool
+	existed    bool
 }
 
 // K8sCreate: MCP tool handler.
 // Python: k8s_create(yaml_content, namespace=None)
+// Extra: atomic=true stops at the first failing document and deletes the objects
+// this call already created (best-effort), reporting the rollback.
+// Extra: check_quota=true projects the manifest's requests, limits and object
+// counts against the namespace ResourceQuotas first (see quotaPreflight) and
+// returns the projection; with enforce=true nothing is created if it would exceed.
+// Documents of a --protected-kinds kind fail unless confirm=true.
 func K8sCreate(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	yamlContent := getStringArg(args, "yaml_content", "yaml")
 	namespace := getStringArg(args, "namespace")
+	atomic := boolFromArgs(args, "atomic", false)
+	confirm := boolFromArgs(args, "confirm", false)
+
+	var preflight *quotaPreflightReport
+	if boolFromArgs(args, "check_quota", false) && strings.TrimSpace(yamlContent) != "" {
+		report, err := quotaPreflight(ctx, yamlContent, namespace)
+		if err != nil {
+			return textErrorResult("Error: quota preflight: " + err.Error()), nil, nil
+		}
+		if report.Exceeds && boolFromArgs(args, "enforce", false) {
+			b, _ := json.MarshalIndent(map[string]any{
+				"error":           "the manifest would exceed a ResourceQuota; nothing was created",
+				"quota_preflight": report,
+			}, "", "  ")
+			return textErrorResult(string(b)), nil, nil
+		}
+		preflight = report
+	}
 
-	out, err := k8sCreateOrApply(ctx, yamlContent, namespace, false)
+	out, err := k8sCreateOrApply(ctx, yamlContent, namespace, false, atomic, confirm)
 	if err != nil {
 		return textErrorResult(err.Error()), nil, nil
 	}
+	if preflight != nil {
+		b, _ := json.MarshalIndent(map[string]any{
+			"quota_preflight": preflight,
+			"results":         json.RawMessage(out),
+		}, "", "  ")
+		out = string(b)
+	}
 	return textOKResult(out), nil, nil
 }
 
 // K8sApply: MCP tool handler (Server-Side Apply).
 // Python: k8s_apply(yaml_content, namespace=None)
+// Extra: prune=true + prune_selector deletes live objects matching the selector that
+// are not in the manifest (like kubectl apply --prune).
+// Extra: atomic=true as for K8sCreate; only objects that did not exist before are
+// deleted, updates to existing objects are not reverted.
+// Each applied object carries an "ownership" summary from metadata.managedFields:
+// the fields the mcp-k8s manager owns, fields it took from other managers
+// (Force=true), and how many fields each other manager still owns.
+// Documents of a --protected-kinds kind fail unless confirm=true.
 func K8sApply(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	yamlContent := getStringArg(args, "yaml_content", "yaml")
 	namespace := getStringArg(args, "namespace")
+	atomic := boolFromArgs(args, "atomic", false)
+	confirm := boolFromArgs(args, "confirm", false)
+
+	if boolFromArgs(args, "prune", false) {
+		if atomic {
+			return textErrorResult("Error: atomic cannot be combined with prune"), nil, nil
+		}
+		out, err := k8sApplyPrune(ctx, yamlContent, namespace, getStringArg(args, "prune_selector", "selector"), confirm)
+		if err != nil {
+			return textErrorResult(err.Error()), nil, nil
+		}
+		return textOKResult(out), nil, nil
+	}
 
-	out, err := k8sCreateOrApply(ctx, yamlContent, namespace, true)
+	out, err := k8sCreateOrApply(ctx, yamlContent, namespace, true, atomic, confirm)
 	if err != nil {
 		return textErrorResult(err.Error()), nil, nil
 	}
 	return textOKResult(out), nil, nil
 }
 
-func k8sCreateOrApply(ctx context.Context, yamlContent string, namespace string, apply bool) (string, error) {
+// k8sCreateOrApply creates or applies every document; confirm lets it change
+// objects of --protected-kinds (see checkProtected).
+func k8sCreateOrApply(ctx context.Context, yamlContent string, namespace string, apply bool, atomic bool, confirm bool) (string, error) {
 	if strings.TrimSpace(yamlContent) == "" {
 		// Keep consistent with your other tools: return an error-ish message but not Go error.
 		// (If you prefer IsError=true, we can flip this.)
 		return `{"error":"No valid YAML/JSON content provided"}`, nil
 	}
+	if atomic && deleteDisabled {
+		return "", fmt.Errorf("Error: Delete operations are not allowed. atomic needs them to roll back.")
+	}
 
-	dyn, err := GetDynamicClient()
+	// Applies always read the live object first so the ownership summary can
+	// report fields a forced apply took from other managers.
+	results, err := createOrApplyDocs(ctx, yamlContent, namespace, apply, apply || atomic, atomic, confirm)
 	if err != nil {
 		return "", err
 	}
-	mapper, err := GetRESTMapper()
+
+	var pretty []byte
+	if atomic {
+		pretty, err = json.MarshalIndent(rollbackIfFailed(ctx, results), "", "  ")
+	} else {
+		pretty, err = json.MarshalIndent(results, "", "  ")
+	}
 	if err != nil {
 		return "", err
 	}
+	return string(pretty), nil
+}
+
+// createOrApplyDocs does the per-document work for create/apply. With trackExisting,
+// apply does a GET first so callers can tell created from updated objects (and the
+// ownership summary can name fields taken from other managers). With
+// stopOnError, documents after the first failure are not attempted.
+func createOrApplyDocs(ctx context.Context, yamlContent string, namespace string, apply bool, trackExisting bool, stopOnError bool, confirm bool) ([]createResult, error) {
+	dyn, err := GetDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := GetRESTMapper()
+	if err != nil {
+		return nil, err
+	}
 
 	dec := k8syaml.NewYAMLOrJSONDecoder(strings.NewReader(yamlContent), 4096)
 
 	results := make([]createResult, 0, 4)
 
 	for {
+		if stopOnError && len(results) > 0 && results[len(results)-1].Status == "error" {
+			break
+		}
+
 		var raw map[string]any
 		if err := dec.Decode(&raw); err != nil {
 			if errors.Is(err, io.EOF) {
@@ -121,7 +218,7 @@ func k8sCreateOrApply(ctx context.Context, yamlContent string, namespace string,
 			}
 			ns = u.GetNamespace()
 			if ns == "" {
-				ns = "default"
+				ns = defaultNamespace(ctx)
 				u.SetNamespace(ns)
 			}
 		} else {
@@ -129,6 +226,19 @@ func k8sCreateOrApply(ctx context.Context, yamlContent string, namespace string,
 		}
 
 		gvr := mapping.Resource
+		action := "creating"
+		if apply {
+			action = "applying"
+		}
+		if err := checkProtectedConfirmed(gvr, u.GetName(), confirm, action); err != nil {
+			results = append(results, createResult{
+				Status:  "error",
+				Message: err.Error(),
+				Object:  raw,
+				GVR:     gvr.String(),
+			})
+			continue
+		}
 
 		// Important: dynamic.Interface.Resource(...) returns NamespaceableResourceInterface,
 		// but Create/Patch are on ResourceInterface. Keep it typed correctly.
@@ -162,9 +272,31 @@ func k8sCreateOrApply(ctx context.Context, yamlContent string, namespace string,
 				continue
 			}
 
+			existed := false
+			var before *unstructured.Unstructured
+			if trackExisting {
+				cur, err := resIf.Get(ctx, name, metav1.GetOptions{})
+				switch {
+				case err == nil:
+					existed = true
+					before = cur
+				case apierrors.IsNotFound(err):
+				case stopOnError:
+					// atomic: an object of unknown state could exist, and the
+					// rollback would then delete it; don't apply it at all.
+					results = append(results, createResult{
+						Status:  "error",
+						Message: fmt.Sprintf("cannot check whether it exists: %v", err),
+						Object:  raw,
+						GVR:     gvr.String(),
+					})
+					continue
+				}
+			}
+
 			force := true
 			out, err := resIf.Patch(ctx, name, types.ApplyPatchType, patchBytes, metav1.PatchOptions{
-				FieldManager: "mcp-k8s",
+				FieldManager: applyFieldManager,
 				Force:        &force,
 			})
 			if err != nil {
@@ -173,14 +305,19 @@ func k8sCreateOrApply(ctx context.Context, yamlContent string, namespace string,
 					Message: err.Error(),
 					Object:  raw,
 					GVR:     gvr.String(),
+					Webhook: webhookForError(ctx, err.Error()),
 				})
 				continue
 			}
 
 			results = append(results, createResult{
-				Status: "applied",
-				Result: out.Object,
-				GVR:    gvr.String(),
+				Status:     "applied",
+				Result:     out.Object,
+				GVR:        gvr.String(),
+				Ownership:  ownershipSummary(before, out),
+				gvr:        gvr,
+				namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+				existed:    existed,
 			})
 			continue
 		}
@@ -192,20 +329,83 @@ func k8sCreateOrApply(ctx context.Context, yamlContent string, namespace string,
 				Message: err.Error(),
 				Object:  raw,
 				GVR:     gvr.String(),
+				Webhook: webhookForError(ctx, err.Error()),
 			})
 			continue
 		}
 
 		results = append(results, createResult{
-			Status: "created",
-			Result: out.Object,
-			GVR:    gvr.String(),
+			Status:     "created",
+			Result:     out.Object,
+			GVR:        gvr.String(),
+			gvr:        gvr,
+			namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
 		})
 	}
 
-	pretty, err := json.MarshalIndent(results, "", "  ")
+	return results, nil
+}
+
+type rollbackResult struct {
+	GVR       string `json:"gvr"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+// rollbackIfFailed deletes, newest first, the objects this call created when any
+// document failed. Objects that existed before an apply are left alone.
+func rollbackIfFailed(ctx context.Context, results []createResult) map[string]any {
+	failed := false
+	for _, r := range results {
+		if r.Status == "error" {
+			failed = true
+			break
+		}
+	}
+	out := map[string]any{
+		"atomic":  true,
+		"failed":  failed,
+		"results": results,
+	}
+	if !failed {
+		return out
+	}
+
+	dyn, err := GetDynamicClient()
 	if err != nil {
-		return "", err
+		out["rollback_error"] = err.Error()
+		return out
 	}
-	return string(pretty), nil
+
+	policy := metav1.DeletePropagationBackground
+	rollback := []rollbackResult{}
+	for i := len(results) - 1; i >= 0; i-- {
+		r := results[i]
+		if r.Status == "error" || r.existed || r.Result == nil {
+			continue
+		}
+		obj := &unstructured.Unstructured{Object: r.Result}
+		rr := rollbackResult{GVR: r.GVR, Namespace: obj.GetNamespace(), Name: obj.GetName(), Status: "deleted"}
+
+		var resIf dynamic.ResourceInterface = dyn.Resource(r.gvr)
+		if r.namespaced {
+			resIf = dyn.Resource(r.gvr).Namespace(obj.GetNamespace())
+		}
+		// The UID precondition keeps us from deleting a same-named object someone
+		// else created in the meantime.
+		uid := obj.GetUID()
+		err := resIf.Delete(ctx, obj.GetName(), metav1.DeleteOptions{
+			PropagationPolicy: &policy,
+			Preconditions:     &metav1.Preconditions{UID: &uid},
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			rr.Status = "error"
+			rr.Message = err.Error()
+		}
+		rollback = append(rollback, rr)
+	}
+	out["rollback"] = rollback
+	return out
 }
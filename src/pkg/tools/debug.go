@@ -0,0 +1,478 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultDebugImage = "busybox:1.36"
+	// defaultDebugLifetime keeps the debug container alive (it runs `sleep`) so
+	// it can be exec'd into after the tool returns.
+	defaultDebugLifetime = 3600
+)
+
+// K8sDebug ports `kubectl debug <pod> --image=... --target=...`: it adds an
+// ephemeral container to a running pod through the pods/ephemeralcontainers
+// subresource, waits for it to start and optionally runs a command in it.
+//
+// Ephemeral containers cannot be removed or restarted; the container exits when
+// its sleep (lifetime_seconds) ends and stays listed in the pod spec.
+//
+// Args:
+// - pod_name (required); namespace defaults to defaultNamespace()
+// - image: default busybox:1.36
+// - target_container: share this container's process namespace (optional)
+// - container_name: default "debugger-<random>"
+// - lifetime_seconds: how long the container stays up, default 3600
+// - command: optional, list or shell string to exec once the container runs
+// - timeout: seconds to wait for the container to start, default 60
+func K8sDebug(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	podName := getStringArg(args, "pod_name", "name")
+	namespace := getStringArg(args, "namespace")
+	image := getStringArg(args, "image")
+	target := getStringArg(args, "target_container")
+	containerName := getStringArg(args, "container_name")
+	lifetime := intFromArgsDefault(args, "lifetime_seconds", defaultDebugLifetime)
+	timeout := intFromArgsDefault(args, "timeout", 60)
+
+	if strings.TrimSpace(podName) == "" {
+		return textErrorResult("pod_name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	if image == "" {
+		image = defaultDebugImage
+	}
+	if lifetime <= 0 {
+		return textErrorResult("Error: lifetime_seconds must be positive"), nil, nil
+	}
+	if timeout <= 0 {
+		timeout = 60
+	}
+
+	var command []string
+	switch c := args["command"].(type) {
+	case string:
+		if strings.TrimSpace(c) != "" {
+			command = []string{"/bin/sh", "-c", c}
+		}
+	default:
+		command = stringSliceFromArgs(args, "command")
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		return textErrorResult(fmt.Sprintf("Error: pod '%s' is %s; ephemeral containers can only be added to running pods", podName, pod.Status.Phase)), nil, nil
+	}
+
+	existing := map[string]bool{}
+	for _, c := range pod.Spec.Containers {
+		existing[c.Name] = true
+	}
+	for _, c := range pod.Spec.InitContainers {
+		existing[c.Name] = true
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		existing[c.Name] = true
+	}
+	if target != "" && !existing[target] {
+		return textErrorResult(fmt.Sprintf("Error: target_container '%s' not found in pod '%s'", target, podName)), nil, nil
+	}
+	if containerName == "" {
+		for containerName == "" || existing[containerName] {
+			containerName = "debugger-" + utilrand.String(5)
+		}
+	} else if existing[containerName] {
+		return textErrorResult(fmt.Sprintf("Error: container '%s' already exists in pod '%s'", containerName, podName)), nil, nil
+	}
+
+	updated := pod.DeepCopy()
+	updated.Spec.EphemeralContainers = append(updated.Spec.EphemeralContainers, v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     containerName,
+			Image:                    image,
+			Command:                  []string{"sleep", strconv.Itoa(lifetime)},
+			ImagePullPolicy:          v1.PullIfNotPresent,
+			TerminationMessagePolicy: v1.TerminationMessageReadFile,
+		},
+		TargetContainerName: target,
+	})
+
+	if _, err := cs.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, podName, updated, metav1.UpdateOptions{}); err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsMethodNotSupported(err) {
+			return textErrorResult("Error: ephemeral containers are not available on this cluster (the pods/ephemeralcontainers subresource is missing or disabled; Kubernetes 1.25+ enables it by default)"), nil, nil
+		}
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	out := map[string]any{
+		"pod":       podName,
+		"namespace": namespace,
+		"container": containerName,
+		"image":     image,
+	}
+	if target != "" {
+		out["target_container"] = target
+	}
+
+	wctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+	state, err := waitEphemeralRunning(wctx, cs, namespace, podName, containerName)
+	out["state"] = state
+	if err != nil {
+		out["running"] = false
+		out["wait_error"] = err.Error()
+		b, _ := json.MarshalIndent(out, "", "  ")
+		return textOKResult(string(b)), nil, nil
+	}
+	out["running"] = true
+
+	if len(command) > 0 {
+		rc, err := getRestConfig()
+		if err != nil {
+			return textErrorResult(err.Error()), nil, nil
+		}
+		stdout, err := execReadAll(ctx, cs, rc, namespace, podName, containerName, command, nil)
+		if err != nil {
+			out["exec_error"] = err.Error()
+		} else {
+			out["output"] = string(stdout)
+		}
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// waitEphemeralRunning polls the pod until the named ephemeral container runs
+// and returns its last observed state ("waiting: ErrImagePull", ...).
+func waitEphemeralRunning(ctx context.Context, cs *kubernetes.Clientset, namespace, podName, container string) (string, error) {
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+
+	state := "pending"
+	for {
+		pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err == nil {
+			for _, st := range pod.Status.EphemeralContainerStatuses {
+				if st.Name != container {
+					continue
+				}
+				switch {
+				case st.State.Running != nil:
+					return "running", nil
+				case st.State.Terminated != nil:
+					return "terminated: " + st.State.Terminated.Reason, fmt.Errorf("debug container exited (%s, exit code %d)", st.State.Terminated.Reason, st.State.Terminated.ExitCode)
+				case st.State.Waiting != nil:
+					state = "waiting: " + st.State.Waiting.Reason
+				}
+			}
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return state, fmt.Errorf("debug container not running: %v", ctx.Err())
+		}
+	}
+}
+
+// nodeDebugLabel marks pods created by k8s_debug_node; the value is the node name.
+const nodeDebugLabel = "mcp.merev/debug-node"
+
+// K8sDebugNode ports `kubectl debug node/<name>`: it runs a privileged pod on
+// the node with hostPID/hostNetwork/hostIPC and the node's root filesystem
+// mounted at /host. Only registered with --allow-node-debug.
+//
+// The pod is named node-debugger-<node> so it can be found and deleted later;
+// an existing, still running debug pod for the node is returned as is.
+//
+// Args:
+// - node_name (required); namespace defaults to defaultNamespace()
+// - image: default busybox:1.36
+// - lifetime_seconds: how long the pod's sleep runs, default 3600
+// - wait (bool, default true) and timeout (seconds, default 120) for Running
+func K8sDebugNode(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	nodeName := getStringArg(args, "node_name", "name")
+	namespace := getStringArg(args, "namespace")
+	image := getStringArg(args, "image")
+	lifetime := intFromArgsDefault(args, "lifetime_seconds", defaultDebugLifetime)
+	wait := boolFromArgs(args, "wait", true)
+	timeout := intFromArgsDefault(args, "timeout", 120)
+
+	if strings.TrimSpace(nodeName) == "" {
+		return textErrorResult("node_name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	if image == "" {
+		image = defaultDebugImage
+	}
+	if lifetime <= 0 {
+		return textErrorResult("Error: lifetime_seconds must be positive"), nil, nil
+	}
+	if timeout <= 0 {
+		timeout = 120
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	if _, err := cs.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{}); err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	podName := nodeDebugPodName(nodeName)
+	out := map[string]any{
+		"pod":       podName,
+		"namespace": namespace,
+		"node":      nodeName,
+		"host_root": "/host",
+	}
+
+	existing, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		if existing.Labels[nodeDebugLabel] != nodeName || isCompletedPod(existing) || existing.DeletionTimestamp != nil {
+			return textErrorResult(fmt.Sprintf("Error: pod '%s' already exists in namespace '%s' (phase %s); delete it first", podName, namespace, existing.Status.Phase)), nil, nil
+		}
+		out["reused"] = true
+	case apierrors.IsNotFound(err):
+		pod := nodeDebugPod(podName, nodeName, image, lifetime)
+		if _, err := cs.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		out["reused"] = false
+	default:
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	if wait {
+		wctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancel()
+		if err := waitPodRunning(wctx, cs, namespace, podName); err != nil {
+			out["running"] = false
+			out["wait_error"] = err.Error()
+		} else {
+			out["running"] = true
+		}
+	}
+	out["cleanup"] = fmt.Sprintf("delete pod %s in namespace %s when done", podName, namespace)
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// nodeDebugPodName keeps the name within the 63-character label value limit
+// kubelet applies to pod hostnames.
+func nodeDebugPodName(node string) string {
+	name := "node-debugger-" + node
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-.")
+	}
+	return name
+}
+
+func nodeDebugPod(name, node, image string, lifetime int) *v1.Pod {
+	privileged := true
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{nodeDebugLabel: node},
+		},
+		Spec: v1.PodSpec{
+			NodeName:      node,
+			HostPID:       true,
+			HostNetwork:   true,
+			HostIPC:       true,
+			RestartPolicy: v1.RestartPolicyNever,
+			// Run on tainted nodes too (control plane, NoExecute, ...).
+			Tolerations: []v1.Toleration{{Operator: v1.TolerationOpExists}},
+			Containers: []v1.Container{{
+				Name:            "debugger",
+				Image:           image,
+				Command:         []string{"sleep", strconv.Itoa(lifetime)},
+				ImagePullPolicy: v1.PullIfNotPresent,
+				SecurityContext: &v1.SecurityContext{Privileged: &privileged},
+				VolumeMounts:    []v1.VolumeMount{{Name: "host-root", MountPath: "/host"}},
+			}},
+			Volumes: []v1.Volume{{
+				Name:         "host-root",
+				VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/"}},
+			}},
+		},
+	}
+}
+
+// podCopyLabel marks pods created by k8s_copy_pod; the value is the source pod.
+const podCopyLabel = "mcp.merev/debug-copy-of"
+
+// K8sCopyPod ports `kubectl debug <pod> --copy-to=<new>`: it creates a
+// standalone copy of a pod to reproduce its environment. The copy drops the
+// node binding, owner references, status, ephemeral containers and the
+// original labels (so Services and controllers ignore it), and is labeled with
+// podCopyLabel instead.
+//
+// Args:
+// - pod_name (required); namespace defaults to defaultNamespace()
+// - new_name: default "<pod>-debug"
+// - container: the container the overrides apply to, default the pod's default container
+// - image_override: replace that container's image
+// - command_override: list or shell string; replaces command and args, and removes
+// the container's probes so e.g. `sleep 3600` isn't restarted
+// - wait (bool, default true) and timeout (seconds, default 120) for Running
+func K8sCopyPod(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	podName := getStringArg(args, "pod_name")
+	namespace := getStringArg(args, "namespace")
+	newName := getStringArg(args, "new_name")
+	container := getStringArg(args, "container")
+	image := getStringArg(args, "image_override", "image")
+	wait := boolFromArgs(args, "wait", true)
+	timeout := intFromArgsDefault(args, "timeout", 120)
+
+	var command []string
+	switch c := args["command_override"].(type) {
+	case string:
+		if strings.TrimSpace(c) != "" {
+			command = []string{"/bin/sh", "-c", c}
+		}
+	default:
+		command = stringSliceFromArgs(args, "command_override")
+	}
+
+	if strings.TrimSpace(podName) == "" {
+		return textErrorResult("pod_name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	if newName == "" {
+		newName = podName + "-debug"
+	}
+	if timeout <= 0 {
+		timeout = 120
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	src, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	if container == "" {
+		container = podDefaultContainerName(src)
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        newName,
+			Namespace:   namespace,
+			Labels:      map[string]string{podCopyLabel: podName},
+			Annotations: src.Annotations,
+		},
+		Spec: *src.Spec.DeepCopy(),
+	}
+	pod.Spec.NodeName = ""
+	pod.Spec.EphemeralContainers = nil
+	// Hostname/subdomain would make the copy answer for the original in DNS.
+	pod.Spec.Hostname = ""
+	pod.Spec.Subdomain = ""
+
+	found := false
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if c.Name != container {
+			continue
+		}
+		found = true
+		if image != "" {
+			c.Image = image
+		}
+		if len(command) > 0 {
+			c.Command = command
+			c.Args = nil
+			c.LivenessProbe, c.ReadinessProbe, c.StartupProbe = nil, nil, nil
+		}
+	}
+	if !found && (image != "" || len(command) > 0) {
+		return textErrorResult(fmt.Sprintf("Error: container '%s' not found in pod '%s'", container, podName)), nil, nil
+	}
+
+	if _, err := cs.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	out := map[string]any{
+		"pod":         newName,
+		"namespace":   namespace,
+		"copied_from": podName,
+		"container":   container,
+	}
+	if image != "" {
+		out["image"] = image
+	}
+	if len(command) > 0 {
+		out["command"] = command
+	}
+	if wait {
+		wctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancel()
+		if err := waitPodRunning(wctx, cs, namespace, newName); err != nil {
+			out["running"] = false
+			out["wait_error"] = err.Error()
+		} else {
+			out["running"] = true
+		}
+	}
+	out["cleanup"] = fmt.Sprintf("delete pod %s in namespace %s when done (copies are labeled %s=%s)", newName, namespace, podCopyLabel, podName)
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+func waitPodRunning(ctx context.Context, cs *kubernetes.Clientset, namespace, podName string) error {
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+
+	for {
+		pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err == nil {
+			switch pod.Status.Phase {
+			case v1.PodRunning:
+				return nil
+			case v1.PodSucceeded, v1.PodFailed:
+				return fmt.Errorf("pod %s before it could be used", strings.ToLower(string(pod.Status.Phase)))
+			}
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return fmt.Errorf("pod not running: %v", ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+type deleteResult struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+// K8sDelete ports `kubectl delete` through the dynamic client, by name or by
+// label selector. Protected kinds (--protected-kinds) need confirm=true.
+//
+// Args:
+// - resource_type (required); name or label_selector (one of them)
+// - namespace defaults to defaultNamespace(); all_namespaces (bool, selector only)
+// - grace_period (int seconds; 0 with force=true deletes immediately)
+// - propagation: "background" (default), "foreground" or "orphan"
+// - dry_run (bool): server-side dry run
+// - confirm (bool): required for protected kinds
+func K8sDelete(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType := getStringArg(args, "resource_type", "resource")
+	name := getStringArg(args, "name", "resource_name")
+	namespace := getStringArg(args, "namespace")
+	selector := getStringArg(args, "label_selector", "selector")
+	allNamespaces := boolFromArgs(args, "all_namespaces", false)
+	dryRun := boolFromArgs(args, "dry_run", false)
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if (name == "") == (selector == "") {
+		return textErrorResult("Error: exactly one of name or label_selector is required"), nil, nil
+	}
+	if allNamespaces && name != "" {
+		return textErrorResult("Error: all_namespaces needs label_selector, not name"), nil, nil
+	}
+	if allNamespaces {
+		namespace = metav1.NamespaceAll
+	} else if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	opts := metav1.DeleteOptions{}
+	if gp, ok := intFromArgs(args, "grace_period"); ok && gp >= 0 {
+		if gp == 0 && !boolFromArgs(args, "force", false) {
+			return textErrorResult("Error: grace_period=0 deletes immediately; set force=true to confirm"), nil, nil
+		}
+		g := int64(gp)
+		opts.GracePeriodSeconds = &g
+	}
+	switch p := strings.ToLower(getStringArg(args, "propagation")); p {
+	case "", "background":
+		policy := metav1.DeletePropagationBackground
+		opts.PropagationPolicy = &policy
+	case "foreground":
+		policy := metav1.DeletePropagationForeground
+		opts.PropagationPolicy = &policy
+	case "orphan":
+		policy := metav1.DeletePropagationOrphan
+		opts.PropagationPolicy = &policy
+	default:
+		return textErrorResult(fmt.Sprintf("Error: unsupported propagation '%s' (expected background, foreground or orphan)", p)), nil, nil
+	}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
+	}
+	target := name
+	if target == "" {
+		target = "(" + selector + ")"
+	}
+	if err := checkProtected(gvr, target, args, "deleting"); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	resourceFor := func(ns string) dynamic.ResourceInterface {
+		if namespaced {
+			return dyn.Resource(gvr).Namespace(ns)
+		}
+		return dyn.Resource(gvr)
+	}
+
+	var targets []unstructured.Unstructured
+	if name != "" {
+		obj := unstructured.Unstructured{}
+		obj.SetName(name)
+		if namespaced {
+			obj.SetNamespace(namespace)
+		}
+		targets = append(targets, obj)
+	} else {
+		list, err := resourceFor(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		targets = list.Items
+	}
+	if len(targets) == 0 {
+		return textOKResult(fmt.Sprintf("No %s found matching %q", gvr.Resource, selector)), nil, nil
+	}
+
+	results := make([]deleteResult, 0, len(targets))
+	for _, t := range targets {
+		r := deleteResult{Namespace: t.GetNamespace(), Name: t.GetName(), Status: "deleted"}
+		if dryRun {
+			r.Status = "deleted (dry run)"
+		}
+		if err := resourceFor(t.GetNamespace()).Delete(ctx, t.GetName(), opts); err != nil {
+			if name != "" {
+				return textErrorResult(formatK8sErr(err)), nil, nil
+			}
+			r.Status = "error"
+			r.Message = formatK8sErr(err)
+		}
+		results = append(results, r)
+	}
+
+	b, _ := json.MarshalIndent(map[string]any{
+		"resource": gvr.Resource,
+		"results":  results,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// K8sDeploymentLogs shows logs of a Deployment's pods without listing them
+// first. Only pods of the current ReplicaSet (the deployment's revision) are
+// used; during a rollout, pods of older revisions are left out. Replicas are
+// indexed from 0 in creation order (oldest first, name as tie-break).
+//
+// Args:
+// - name (required); namespace defaults to defaultNamespace()
+// - replica_index: one replica; omitted = all of them, one section per pod
+// - tail (default 100 per pod), since, timestamps, previous as in k8s_logs
+// - container: defaults to each pod's default container
+func K8sDeploymentLogs(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	name := getStringArg(args, "name", "deployment")
+	namespace := getStringArg(args, "namespace")
+	container := getStringArg(args, "container")
+
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	index, hasIndex := intFromArgs(args, "replica_index")
+	if hasIndex && index < 0 {
+		return textErrorResult("Error: replica_index must be >= 0"), nil, nil
+	}
+	tailLines, sinceSeconds, err := logWindowFromArgs(args)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+	if _, set := args["tail"]; !set {
+		tail := int64(100)
+		tailLines = &tail
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dep, err := cs.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	rss, err := deploymentRevisions(ctx, cs, dep)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	if len(rss) == 0 {
+		return textOKResult(fmt.Sprintf("Deployment %s/%s has no ReplicaSet yet", namespace, name)), nil, nil
+	}
+	current := currentReplicaSet(dep, rss)
+
+	list, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelsToSelector(current.Spec.Selector.MatchLabels)})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	pods := make([]v1.Pod, 0, len(list.Items))
+	for _, p := range list.Items {
+		if ref := metav1.GetControllerOf(&p); ref != nil && ref.UID == current.UID && !isCompletedPod(&p) {
+			pods = append(pods, p)
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		ti, tj := pods[i].CreationTimestamp, pods[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	if len(pods) == 0 {
+		return textOKResult(fmt.Sprintf("Deployment %s/%s has no running pods in ReplicaSet %s (revision %s)", namespace, name, current.Name, revisionString(current))), nil, nil
+	}
+
+	header := fmt.Sprintf("Deployment %s/%s: ReplicaSet %s (revision %s), %d pod(s)", namespace, name, current.Name, revisionString(current), len(pods))
+	if hasIndex {
+		if index >= len(pods) {
+			return textErrorResult(fmt.Sprintf("Error: replica_index %d out of range; deployment %s/%s has %d current pod(s) (0-%d)", index, namespace, name, len(pods), len(pods)-1)), nil, nil
+		}
+		header += fmt.Sprintf(", replica %d", index)
+		pods = pods[index : index+1]
+	}
+
+	targets := make([]podLogTarget, 0, len(pods))
+	for i := range pods {
+		c := container
+		if c == "" {
+			c = podDefaultContainerName(&pods[i])
+		}
+		targets = append(targets, podLogTarget{pod: &pods[i], container: c})
+	}
+	logs := aggregatePodLogs(ctx, cs, targets, v1.PodLogOptions{
+		Previous:     boolFromArgs(args, "previous", false),
+		Timestamps:   boolFromArgs(args, "timestamps", false),
+		TailLines:    tailLines,
+		SinceSeconds: sinceSeconds,
+	})
+	return textOKResult(header + "\n\n" + logs), nil, nil
+}
@@ -3,30 +3,42 @@ package tools
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 )
 
 // K8sDescribe mirrors describe.py k8s_describe(resource_type, name, namespace, selector, all_namespaces)
+// Extra: version reads a multi-version resource (e.g. a CRD) through that API version.
+// Extra: output "text" (default) or "json" for structured facts (metadata, spec
+// highlights, status, events; see describeObjectJSON), an array when listing.
 func K8sDescribe(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	resourceType, _ := args["resource_type"].(string)
 	name, _ := args["name"].(string)
 	namespace, _ := args["namespace"].(string)
 	selector, _ := args["selector"].(string)
 	allNamespaces := boolFromArgs(args, "all_namespaces", false)
+	output := strings.ToLower(getStringArg(args, "output"))
 
 	if strings.TrimSpace(resourceType) == "" {
 		return textErrorResult("resource_type is required"), nil, nil
 	}
+	switch output {
+	case "", "text", "json":
+	default:
+		return textErrorResult(fmt.Sprintf("Error: unsupported output '%s' (expected text or json)", output)), nil, nil
+	}
 
 	// Default namespace like Python (only if not all namespaces)
 	if !allNamespaces && namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	disc, err := getDiscovery()
@@ -46,6 +58,9 @@ func K8sDescribe(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 	if !found {
 		return textErrorResult(fmt.Sprintf("Error: resource type '%s' not found", resourceType)), nil, nil
 	}
+	if gvr, err = withVersion(disc, gvr, getStringArg(args, "version")); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
 
 	ri := dyn.Resource(gvr)
 
@@ -87,16 +102,10 @@ func K8sDescribe(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 			obj = o
 		}
 
-		desc := formatResourceDescription(obj)
-
-		evs := fetchEventsForObject(ctx, cs, obj)
-		if len(evs) > 0 {
-			desc += "\nEvents:\n"
-			for _, e := range evs {
-				ts := formatEventTime(e)
-				desc += fmt.Sprintf("  %s: %s %s: %s\n", ts, e.Type, e.Reason, e.Message)
-			}
+		if output == "json" {
+			return marshalUnstructured(describeObjectJSON(ctx, cs, obj)), nil, nil
 		}
+		desc := describeObject(ctx, cs, obj)
 
 		return textOKResult(desc), nil, nil
 	}
@@ -125,6 +134,13 @@ func K8sDescribe(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 		list = l
 	}
 
+	if output == "json" {
+		out := make([]map[string]any, 0, len(list.Items))
+		for i := range list.Items {
+			out = append(out, describeObjectJSON(ctx, cs, &list.Items[i]))
+		}
+		return marshalUnstructured(out), nil, nil
+	}
 	if len(list.Items) == 0 {
 		return textOKResult(fmt.Sprintf("No %s found", resourceType)), nil, nil
 	}
@@ -132,16 +148,7 @@ func K8sDescribe(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 	var parts []string
 	for i := range list.Items {
 		obj := &list.Items[i]
-		desc := formatResourceDescription(obj)
-
-		evs := fetchEventsForObject(ctx, cs, obj)
-		if len(evs) > 0 {
-			desc += "\nEvents:\n"
-			for _, e := range evs {
-				ts := formatEventTime(e)
-				desc += fmt.Sprintf("  %s: %s %s: %s\n", ts, e.Type, e.Reason, e.Message)
-			}
-		}
+		desc := describeObject(ctx, cs, obj)
 
 		parts = append(parts, desc)
 	}
@@ -149,6 +156,163 @@ func K8sDescribe(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 	return textOKResult(strings.Join(parts, "\n\n")), nil, nil
 }
 
+// describeObject is the generic description plus events; pods also get
+// container status and scheduling sections.
+func describeObject(ctx context.Context, cs *kubernetes.Clientset, obj *unstructured.Unstructured) string {
+	desc := formatResourceDescription(obj)
+
+	evs := fetchEventsForObject(ctx, cs, obj)
+	if obj.GetKind() == "Pod" {
+		desc += describeContainerStatuses(podContainerStatuses(obj))
+		desc += describePodScheduling(obj, evs)
+	}
+	if len(evs) > 0 {
+		desc += "\nEvents:\n"
+		for _, e := range evs {
+			ts := formatEventTime(e)
+			desc += fmt.Sprintf("  %s: %s %s: %s\n", ts, e.Type, e.Reason, e.Message)
+		}
+	}
+	return desc
+}
+
+// describePodScheduling answers "where does this pod run, and if it is
+// Pending, why not": node, nodeSelector, affinity, tolerations and, for
+// unscheduled pods, the PodScheduled condition and FailedScheduling events.
+func describePodScheduling(obj *unstructured.Unstructured, evs []eventLike) string {
+	var pod v1.Pod
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod); err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("Scheduling:\n")
+
+	node := pod.Spec.NodeName
+	if node == "" {
+		node = "<none>"
+	}
+	b.WriteString(fmt.Sprintf("  Node: %s\n", node))
+	if pod.Status.Phase != "" {
+		b.WriteString(fmt.Sprintf("  Phase: %s\n", pod.Status.Phase))
+	}
+	if pod.Spec.SchedulerName != "" && pod.Spec.SchedulerName != v1.DefaultSchedulerName {
+		b.WriteString(fmt.Sprintf("  Scheduler: %s\n", pod.Spec.SchedulerName))
+	}
+	if pod.Spec.PriorityClassName != "" {
+		b.WriteString(fmt.Sprintf("  Priority Class: %s\n", pod.Spec.PriorityClassName))
+	}
+
+	if len(pod.Spec.NodeSelector) > 0 {
+		keys := make([]string, 0, len(pod.Spec.NodeSelector))
+		for k := range pod.Spec.NodeSelector {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteString("  Node-Selectors:\n")
+		for _, k := range keys {
+			b.WriteString(fmt.Sprintf("    %s=%s\n", k, pod.Spec.NodeSelector[k]))
+		}
+	}
+
+	if a := pod.Spec.Affinity; a != nil {
+		if a.NodeAffinity != nil {
+			if req := a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; req != nil {
+				b.WriteString("  Node Affinity (required):\n")
+				for _, term := range req.NodeSelectorTerms {
+					b.WriteString("    " + nodeSelectorTermString(term) + "\n")
+				}
+			}
+			for _, pref := range a.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+				b.WriteString(fmt.Sprintf("  Node Affinity (preferred, weight %d): %s\n", pref.Weight, nodeSelectorTermString(pref.Preference)))
+			}
+		}
+		if a.PodAffinity != nil {
+			b.WriteString(fmt.Sprintf("  Pod Affinity: %d required, %d preferred term(s)\n",
+				len(a.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution),
+				len(a.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution)))
+		}
+		if a.PodAntiAffinity != nil {
+			b.WriteString(fmt.Sprintf("  Pod Anti-Affinity: %d required, %d preferred term(s)\n",
+				len(a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution),
+				len(a.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)))
+		}
+	}
+
+	if len(pod.Spec.Tolerations) > 0 {
+		b.WriteString("  Tolerations:\n")
+		for _, t := range pod.Spec.Tolerations {
+			b.WriteString("    " + tolerationString(t) + "\n")
+		}
+	}
+
+	if pod.Spec.NodeName == "" {
+		for _, c := range pod.Status.Conditions {
+			if c.Type == v1.PodScheduled && c.Status != v1.ConditionTrue {
+				b.WriteString(fmt.Sprintf("  Not Scheduled: %s: %s\n", c.Reason, c.Message))
+			}
+		}
+		var failures []eventLike
+		for _, e := range evs {
+			if e.Reason == "FailedScheduling" {
+				failures = append(failures, e)
+			}
+		}
+		if len(failures) > 0 {
+			// Show the latest few; the scheduler repeats itself a lot.
+			sort.SliceStable(failures, func(i, j int) bool { return formatEventTime(failures[i]) > formatEventTime(failures[j]) })
+			if len(failures) > 3 {
+				failures = failures[:3]
+			}
+			b.WriteString("  Scheduling Failures:\n")
+			for _, e := range failures {
+				b.WriteString(fmt.Sprintf("    %s: %s\n", formatEventTime(e), e.Message))
+			}
+		}
+	}
+	return b.String()
+}
+
+func nodeSelectorTermString(term v1.NodeSelectorTerm) string {
+	var parts []string
+	for _, list := range [][]v1.NodeSelectorRequirement{term.MatchExpressions, term.MatchFields} {
+		for _, r := range list {
+			switch r.Operator {
+			case v1.NodeSelectorOpExists, v1.NodeSelectorOpDoesNotExist:
+				parts = append(parts, fmt.Sprintf("%s %s", r.Key, r.Operator))
+			default:
+				parts = append(parts, fmt.Sprintf("%s %s [%s]", r.Key, r.Operator, strings.Join(r.Values, ",")))
+			}
+		}
+	}
+	if len(parts) == 0 {
+		return "<empty term>"
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// tolerationString renders a toleration the way kubectl describe does, e.g.
+// "node.kubernetes.io/not-ready:NoExecute op=Exists for 300s".
+func tolerationString(t v1.Toleration) string {
+	s := t.Key
+	if t.Value != "" {
+		s += "=" + t.Value
+	}
+	if t.Effect != "" {
+		s += ":" + string(t.Effect)
+	}
+	if t.Operator == v1.TolerationOpExists && t.Value == "" {
+		if s == "" {
+			s = "<all>"
+		}
+		s += " op=Exists"
+	}
+	if t.TolerationSeconds != nil {
+		s += fmt.Sprintf(" for %ds", *t.TolerationSeconds)
+	}
+	return s
+}
+
 // ---- Events (typed clientset) ----
 
 func fetchEventsForObject(ctx context.Context, cs *kubernetes.Clientset, obj *unstructured.Unstructured) []eventLike {
@@ -183,6 +347,8 @@ func fetchEventsForObject(ctx context.Context, cs *kubernetes.Clientset, obj *un
 			Last:         e.LastTimestamp,
 			EventTime:    e.EventTime,
 			CreationTime: e.CreationTimestamp,
+			Count:        e.Count,
+			FieldPath:    e.InvolvedObject.FieldPath,
 		})
 	}
 	return out
@@ -196,6 +362,8 @@ type eventLike struct {
 	Last         metav1.Time
 	EventTime    metav1.MicroTime
 	CreationTime metav1.Time
+	Count        int32
+	FieldPath    string // e.g. spec.containers{app}
 }
 
 func formatEventTime(e eventLike) string {
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// describeObjectJSON is the structured form of describeObject (output=json):
+// metadata, the spec fields describe would highlight for the kind, the
+// normalized status (see extractStatusSummary), per-container status for
+// pods and the object's events.
+func describeObjectJSON(ctx context.Context, cs *kubernetes.Clientset, obj *unstructured.Unstructured) map[string]any {
+	meta := map[string]any{
+		"kind": obj.GetKind(),
+		"name": obj.GetName(),
+	}
+	if ns := obj.GetNamespace(); ns != "" {
+		meta["namespace"] = ns
+	}
+	if labels := obj.GetLabels(); len(labels) > 0 {
+		meta["labels"] = labels
+	}
+	if ann := obj.GetAnnotations(); len(ann) > 0 {
+		meta["annotations"] = ann
+	}
+	if ct := formatMetaTime(obj.GetCreationTimestamp()); ct != "" {
+		meta["creation_timestamp"] = ct
+	}
+	if refs := obj.GetOwnerReferences(); len(refs) > 0 {
+		owners := make([]string, 0, len(refs))
+		for _, r := range refs {
+			owners = append(owners, r.Kind+"/"+r.Name)
+		}
+		meta["owners"] = owners
+	}
+
+	out := map[string]any{
+		"metadata": meta,
+		"status":   extractStatusSummary(obj),
+	}
+	if spec := describeSpecHighlights(obj); len(spec) > 0 {
+		out["spec"] = spec
+	}
+	if statuses := podContainerStatuses(obj); statuses != nil {
+		out["container_statuses"] = statuses
+	}
+
+	events := []map[string]any{}
+	for _, e := range fetchEventsForObject(ctx, cs, obj) {
+		ev := map[string]any{
+			"time":    formatEventTime(e),
+			"type":    e.Type,
+			"reason":  e.Reason,
+			"message": e.Message,
+		}
+		if e.Count > 1 {
+			ev["count"] = e.Count
+		}
+		events = append(events, ev)
+	}
+	out["events"] = events
+	return out
+}
+
+// describeSpecHighlights picks the spec fields worth reading first for common
+// kinds; other kinds return nil and rely on status and events.
+func describeSpecHighlights(obj *unstructured.Unstructured) map[string]any {
+	o := obj.Object
+	spec := map[string]any{}
+	setIf := func(key string, path ...string) {
+		if v, found, _ := unstructured.NestedFieldCopy(o, path...); found && v != nil {
+			spec[key] = v
+		}
+	}
+
+	switch strings.ToLower(obj.GetKind()) {
+	case "deployment", "statefulset", "replicaset":
+		setIf("replicas", "spec", "replicas")
+		setIf("selector", "spec", "selector")
+		setIf("strategy", "spec", "strategy", "type")
+		setIf("update_strategy", "spec", "updateStrategy", "type")
+	case "daemonset":
+		setIf("selector", "spec", "selector")
+		setIf("update_strategy", "spec", "updateStrategy", "type")
+	case "job":
+		setIf("completions", "spec", "completions")
+		setIf("parallelism", "spec", "parallelism")
+		setIf("backoff_limit", "spec", "backoffLimit")
+	case "cronjob":
+		setIf("schedule", "spec", "schedule")
+		setIf("suspend", "spec", "suspend")
+		setIf("concurrency_policy", "spec", "concurrencyPolicy")
+	case "service":
+		setIf("type", "spec", "type")
+		setIf("cluster_ip", "spec", "clusterIP")
+		setIf("selector", "spec", "selector")
+		setIf("ports", "spec", "ports")
+	case "node":
+		setIf("unschedulable", "spec", "unschedulable")
+		setIf("taints", "spec", "taints")
+		setIf("node_info", "status", "nodeInfo")
+	case "persistentvolumeclaim":
+		setIf("storage_class", "spec", "storageClassName")
+		setIf("access_modes", "spec", "accessModes")
+		setIf("requested", "spec", "resources", "requests", "storage")
+		setIf("volume", "spec", "volumeName")
+	}
+
+	if path := podSpecPathForKind(obj.GetKind()); path != nil {
+		if m, found, _ := unstructured.NestedMap(o, path...); found {
+			var ps v1.PodSpec
+			if runtime.DefaultUnstructuredConverter.FromUnstructured(m, &ps) == nil {
+				describePodSpec(spec, &ps)
+			}
+		}
+	}
+	if obj.GetKind() == "Pod" {
+		var pod v1.Pod
+		if runtime.DefaultUnstructuredConverter.FromUnstructured(o, &pod) == nil {
+			spec["qos_class"] = string(podQOSClass(&pod))
+		}
+	}
+	return spec
+}
+
+// describePodSpec adds containers and the scheduling fields of a pod (template) spec.
+func describePodSpec(spec map[string]any, ps *v1.PodSpec) {
+	containers := make([]map[string]any, 0, len(ps.InitContainers)+len(ps.Containers))
+	add := func(c v1.Container, init bool) {
+		cm := map[string]any{"name": c.Name, "image": c.Image}
+		if init {
+			cm["init"] = true
+		}
+		if len(c.Resources.Requests) > 0 {
+			cm["requests"] = c.Resources.Requests
+		}
+		if len(c.Resources.Limits) > 0 {
+			cm["limits"] = c.Resources.Limits
+		}
+		containers = append(containers, cm)
+	}
+	for _, c := range ps.InitContainers {
+		add(c, true)
+	}
+	for _, c := range ps.Containers {
+		add(c, false)
+	}
+	spec["containers"] = containers
+
+	if ps.NodeName != "" {
+		spec["node"] = ps.NodeName
+	}
+	if ps.ServiceAccountName != "" {
+		spec["service_account"] = ps.ServiceAccountName
+	}
+	if ps.PriorityClassName != "" {
+		spec["priority_class"] = ps.PriorityClassName
+	}
+	if len(ps.NodeSelector) > 0 {
+		spec["node_selector"] = ps.NodeSelector
+	}
+	if len(ps.Tolerations) > 0 {
+		tols := make([]string, 0, len(ps.Tolerations))
+		for _, t := range ps.Tolerations {
+			tols = append(tols, tolerationString(t))
+		}
+		spec["tolerations"] = tols
+	}
+	if a := ps.Affinity; a != nil && a.NodeAffinity != nil && a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		terms := []string{}
+		for _, term := range a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			terms = append(terms, nodeSelectorTermString(term))
+		}
+		spec["required_node_affinity"] = terms
+	}
+}
@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// containerStatusInfo is one container of status.(init)containerStatuses in
+// describe output: readiness, restarts and the current and last state.
+type containerStatusInfo struct {
+	Name         string `json:"name"`
+	Init         bool   `json:"init,omitempty"`
+	Image        string `json:"image"`
+	ImageID      string `json:"image_id,omitempty"`
+	Ready        bool   `json:"ready"`
+	Started      *bool  `json:"started,omitempty"`
+	RestartCount int32  `json:"restart_count"`
+	State        string `json:"state"` // running, waiting, terminated or unknown
+	Since        string `json:"since,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	Message      string `json:"message,omitempty"`
+	ExitCode     *int32 `json:"exit_code,omitempty"`
+	LastState    string `json:"last_state,omitempty"`
+}
+
+// podContainerStatuses reads the container statuses of a Pod object, init
+// containers first. It returns nil for other kinds and for pods no kubelet
+// has reported on yet.
+func podContainerStatuses(obj *unstructured.Unstructured) []containerStatusInfo {
+	if obj.GetKind() != "Pod" {
+		return nil
+	}
+	var pod v1.Pod
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod); err != nil {
+		return nil
+	}
+
+	var out []containerStatusInfo
+	add := func(st v1.ContainerStatus, init bool) {
+		info := containerStatusInfo{
+			Name:         st.Name,
+			Init:         init,
+			Image:        st.Image,
+			ImageID:      st.ImageID,
+			Ready:        st.Ready,
+			Started:      st.Started,
+			RestartCount: st.RestartCount,
+			State:        "unknown",
+		}
+		switch s := st.State; {
+		case s.Running != nil:
+			info.State = "running"
+			info.Since = formatMetaTime(s.Running.StartedAt)
+		case s.Waiting != nil:
+			info.State = "waiting"
+			info.Reason = s.Waiting.Reason
+			info.Message = s.Waiting.Message
+		case s.Terminated != nil:
+			info.State = "terminated"
+			info.Since = formatMetaTime(s.Terminated.FinishedAt)
+			info.Reason = s.Terminated.Reason
+			info.Message = s.Terminated.Message
+			code := s.Terminated.ExitCode
+			info.ExitCode = &code
+		}
+		if t := st.LastTerminationState.Terminated; t != nil {
+			info.LastState = fmt.Sprintf("terminated: %s, exit code %d", t.Reason, t.ExitCode)
+			if at := formatMetaTime(t.FinishedAt); at != "" {
+				info.LastState += " at " + at
+			}
+		}
+		out = append(out, info)
+	}
+	for _, st := range pod.Status.InitContainerStatuses {
+		add(st, true)
+	}
+	for _, st := range pod.Status.ContainerStatuses {
+		add(st, false)
+	}
+	return out
+}
+
+// describeContainerStatuses renders podContainerStatuses for text describe.
+func describeContainerStatuses(statuses []containerStatusInfo) string {
+	if len(statuses) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("Containers:\n")
+	for _, c := range statuses {
+		name := c.Name
+		if c.Init {
+			name += " (init)"
+		}
+		b.WriteString(fmt.Sprintf("  %s:\n", name))
+		b.WriteString(fmt.Sprintf("    Image: %s\n", c.Image))
+		if c.ImageID != "" {
+			b.WriteString(fmt.Sprintf("    Image ID: %s\n", c.ImageID))
+		}
+		state := c.State
+		switch {
+		case c.State == "running" && c.Since != "":
+			state += " since " + c.Since
+		case c.State == "waiting" && c.Reason != "":
+			state += " (" + c.Reason + ")"
+		case c.State == "terminated":
+			state += fmt.Sprintf(" (%s, exit code %d)", c.Reason, *c.ExitCode)
+			if c.Since != "" {
+				state += " at " + c.Since
+			}
+		}
+		b.WriteString(fmt.Sprintf("    State: %s\n", state))
+		if c.Message != "" {
+			b.WriteString(fmt.Sprintf("      Message: %s\n", c.Message))
+		}
+		if c.LastState != "" {
+			b.WriteString(fmt.Sprintf("    Last State: %s\n", c.LastState))
+		}
+		b.WriteString(fmt.Sprintf("    Ready: %t\n", c.Ready))
+		if c.Started != nil {
+			b.WriteString(fmt.Sprintf("    Started: %t\n", *c.Started))
+		}
+		b.WriteString(fmt.Sprintf("    Restart Count: %d\n", c.RestartCount))
+	}
+	return b.String()
+}
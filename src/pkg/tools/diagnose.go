@@ -0,0 +1,320 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// diagnoseFinding is one likely problem with the objects showing it.
+type diagnoseFinding struct {
+	Problem string   `json:"problem"`
+	Objects []string `json:"objects,omitempty"`
+	Detail  string   `json:"detail,omitempty"`
+	Hint    string   `json:"hint,omitempty"`
+}
+
+// diagnoseFindings groups findings by problem in the order they were first
+// seen, so ten pods in ImagePullBackOff make one finding, not ten.
+type diagnoseFindings struct {
+	list  []*diagnoseFinding
+	index map[string]*diagnoseFinding
+}
+
+func (f *diagnoseFindings) add(problem, object, detail, hint string) {
+	if f.index == nil {
+		f.index = map[string]*diagnoseFinding{}
+	}
+	d, ok := f.index[problem]
+	if !ok {
+		d = &diagnoseFinding{Problem: problem, Detail: detail, Hint: hint}
+		f.index[problem] = d
+		f.list = append(f.list, d)
+	}
+	if object != "" && !stringInSlice(object, d.Objects) {
+		d.Objects = append(d.Objects, object)
+	}
+}
+
+type diagnoseReplicaSet struct {
+	Name      string   `json:"name"`
+	Revision  string   `json:"revision"`
+	Current   bool     `json:"current"`
+	Desired   int32    `json:"desired"`
+	Ready     int32    `json:"ready"`
+	Available int32    `json:"available"`
+	Images    []string `json:"images"`
+}
+
+type diagnosePod struct {
+	Name       string `json:"name"`
+	ReplicaSet string `json:"replicaset"`
+	Node       string `json:"node,omitempty"`
+	Restarts   int32  `json:"restarts"`
+	State      string `json:"state"`
+}
+
+// K8sDiagnose explains why a Deployment is unhealthy in one call: rollout
+// status, its ReplicaSets, the state of their pods, recent events across the
+// tree, PodDisruptionBudgets covering the pods, and findings that name the
+// likely problem (image pull error, crash loop, failed scheduling or
+// insufficient resources, quota, readiness, progress deadline, PDB block).
+//
+// Args:
+// - resource_type (only deployment is supported), name (required); namespace defaults to defaultNamespace()
+// - events: how many of the most recent events to include (default 20)
+func K8sDiagnose(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType := getStringArg(args, "resource_type")
+	name := getStringArg(args, "name", "resource_name")
+	namespace := getStringArg(args, "namespace")
+	maxEvents := intFromArgsDefault(args, "events", 20)
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	switch strings.ToLower(resourceType) {
+	case "deployment", "deployments", "deploy":
+	default:
+		return textErrorResult(fmt.Sprintf("Error: resource type '%s' is not supported; k8s_diagnose handles deployments", resourceType)), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dep, err := cs.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	rollout, err := rolloutStatusFor(ctx, cs, "deployment", name, namespace)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	rss, err := deploymentRevisions(ctx, cs, dep)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	var findings diagnoseFindings
+	if dep.Spec.Paused {
+		findings.add("rollout paused", "deployment/"+dep.Name, "spec.paused is true, so template changes are not rolled out", "resume it with k8s_rollout_resume")
+	}
+	if dep.Spec.Replicas != nil && *dep.Spec.Replicas == 0 {
+		findings.add("scaled to zero", "deployment/"+dep.Name, "spec.replicas is 0", "")
+	}
+	for _, c := range dep.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == v1.ConditionFalse && c.Reason == "ProgressDeadlineExceeded" {
+			findings.add("progress deadline exceeded", "deployment/"+dep.Name, c.Message, "the new pods never became available; the findings below usually say why")
+		}
+	}
+
+	// The tree: the deployment, its ReplicaSets and their pods.
+	treeNames := map[string]bool{dep.Name: true}
+	rsByUID := map[types.UID]*appsv1.ReplicaSet{}
+	rsRows := make([]diagnoseReplicaSet, 0, len(rss))
+	var current *appsv1.ReplicaSet
+	if len(rss) > 0 {
+		current = currentReplicaSet(dep, rss)
+	}
+	for i := range rss {
+		rs := &rss[i]
+		treeNames[rs.Name] = true
+		rsByUID[rs.UID] = rs
+		row := diagnoseReplicaSet{
+			Name:      rs.Name,
+			Revision:  revisionString(rs),
+			Current:   rs == current,
+			Ready:     rs.Status.ReadyReplicas,
+			Available: rs.Status.AvailableReplicas,
+		}
+		if rs.Spec.Replicas != nil {
+			row.Desired = *rs.Spec.Replicas
+		}
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			row.Images = append(row.Images, c.Image)
+		}
+		// Old, scaled-down ReplicaSets are history, not state.
+		if row.Current || row.Desired > 0 || rs.Status.Replicas > 0 {
+			rsRows = append(rsRows, row)
+		}
+		for _, c := range rs.Status.Conditions {
+			if c.Type == appsv1.ReplicaSetReplicaFailure && c.Status == v1.ConditionTrue {
+				problem := "pod creation failing"
+				if strings.Contains(c.Message, "exceeded quota") {
+					problem = "resource quota exceeded"
+				}
+				findings.add(problem, "replicaset/"+rs.Name, c.Message, "the ReplicaSet cannot create pods; check ResourceQuotas, LimitRanges and admission webhooks")
+			}
+		}
+	}
+
+	podList, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelsToSelector(dep.Spec.Selector.MatchLabels)})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	var pods []*v1.Pod
+	for i := range podList.Items {
+		p := &podList.Items[i]
+		if ref := metav1.GetControllerOf(p); ref != nil && rsByUID[ref.UID] != nil {
+			pods = append(pods, p)
+			treeNames[p.Name] = true
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	evList, err := cs.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	var events []*v1.Event
+	probeFailures := map[string]map[string]bool{} // pod -> "Liveness"/"Readiness"
+	for i := range evList.Items {
+		e := &evList.Items[i]
+		if !treeNames[e.InvolvedObject.Name] {
+			continue
+		}
+		events = append(events, e)
+		if e.InvolvedObject.Kind == "Pod" && e.Reason == "Unhealthy" {
+			for _, probe := range []string{"Liveness", "Readiness"} {
+				if strings.Contains(e.Message, probe) {
+					if probeFailures[e.InvolvedObject.Name] == nil {
+						probeFailures[e.InvolvedObject.Name] = map[string]bool{}
+					}
+					probeFailures[e.InvolvedObject.Name][probe] = true
+				}
+			}
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return eventTime(events[i]).After(eventTime(events[j])) })
+	if maxEvents >= 0 && len(events) > maxEvents {
+		events = events[:maxEvents]
+	}
+
+	podRows := make([]diagnosePod, 0, len(pods))
+	for _, p := range pods {
+		row := diagnosePod{Name: p.Name, Node: p.Spec.NodeName, State: podStateSummary(p)}
+		if ref := metav1.GetControllerOf(p); ref != nil {
+			row.ReplicaSet = ref.Name
+		}
+		for _, st := range p.Status.ContainerStatuses {
+			row.Restarts += st.RestartCount
+		}
+		podRows = append(podRows, row)
+		diagnosePodFindings(&findings, p, probeFailures[p.Name])
+	}
+
+	pdbs, err := cs.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+	if err == nil {
+		podLabels := labels.Set(dep.Spec.Template.Labels)
+		for _, pdb := range pdbs.Items {
+			sel, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil || sel.Empty() || !sel.Matches(podLabels) {
+				continue
+			}
+			if pdb.Status.DisruptionsAllowed == 0 && len(pods) > 0 {
+				findings.add("PodDisruptionBudget blocks evictions", "poddisruptionbudget/"+pdb.Name,
+					fmt.Sprintf("0 disruptions allowed (%d healthy, %d required)", pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy),
+					"node drains and evictions of these pods will wait; rollouts are not blocked by PDBs")
+			}
+		}
+	}
+
+	summary := diagnoseSummary(findings.list, fmtAny(rollout["status"]))
+	eventLines := make([]string, 0, len(events))
+	for _, e := range events {
+		eventLines = append(eventLines, strings.TrimSuffix(formatEventLine(e, ""), "\n"))
+	}
+	if findings.list == nil {
+		findings.list = []*diagnoseFinding{}
+	}
+	out := map[string]any{
+		"deployment":  dep.Name,
+		"namespace":   dep.Namespace,
+		"summary":     summary,
+		"findings":    findings.list,
+		"rollout":     rollout,
+		"replicasets": rsRows,
+		"pods":        podRows,
+		"events":      eventLines,
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// diagnosePodFindings adds what a single pod shows: scheduling failures,
+// container start problems, crash loops and failing probes.
+func diagnosePodFindings(f *diagnoseFindings, p *v1.Pod, probes map[string]bool) {
+	object := "pod/" + p.Name
+	for _, c := range p.Status.Conditions {
+		if c.Type == v1.PodScheduled && c.Status == v1.ConditionFalse {
+			if strings.Contains(c.Message, "Insufficient") {
+				f.add("insufficient resources", object, c.Message, "no node has enough free cpu/memory for the pod's requests: lower the requests or add capacity")
+			} else {
+				f.add("failed scheduling", object, c.Message, "check nodeSelector, affinity, taints/tolerations and PVC binding")
+			}
+		}
+	}
+
+	statuses := append([]v1.ContainerStatus{}, p.Status.InitContainerStatuses...)
+	statuses = append(statuses, p.Status.ContainerStatuses...)
+	for _, st := range statuses {
+		d := diagnoseContainerStatus(st, probes["Liveness"])
+		switch d.WaitingReason {
+		case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+			f.add("image pull error", object, d.WaitingMessage, d.Diagnosis)
+			continue
+		case "CreateContainerConfigError", "CreateContainerError", "RunContainerError":
+			f.add("container cannot start", object, d.WaitingMessage, d.Diagnosis)
+			continue
+		}
+		if d.WaitingReason == "CrashLoopBackOff" || (st.RestartCount > 0 && d.LastTerminated != nil) {
+			f.add("crash loop", object, fmt.Sprintf("container %s restarted %d time(s)", st.Name, st.RestartCount), d.Diagnosis)
+			continue
+		}
+		if st.State.Running != nil && !st.Ready && probes["Readiness"] {
+			f.add("readiness probe failing", object, fmt.Sprintf("container %s is running but not ready", st.Name), "check the readiness probe and the app's health endpoint (k8s_set_probe)")
+		}
+	}
+}
+
+// diagnoseSummary is the plain-language answer: the findings in one line
+// each, or that nothing looks wrong.
+func diagnoseSummary(findings []*diagnoseFinding, rolloutStatus string) string {
+	if len(findings) == 0 {
+		if rolloutStatus == "complete" {
+			return "No problems found: the deployment is fully rolled out and its pods are ready."
+		}
+		return "The rollout is still in progress and no specific problem was found yet; check again shortly or watch with k8s_rollout_watch."
+	}
+	lines := make([]string, 0, len(findings))
+	for _, f := range findings {
+		line := f.Problem
+		if n := len(f.Objects); n > 0 {
+			line += fmt.Sprintf(" (%s", f.Objects[0])
+			if n > 1 {
+				line += fmt.Sprintf(" and %d more", n-1)
+			}
+			line += ")"
+		}
+		if f.Detail != "" {
+			line += ": " + f.Detail
+		}
+		lines = append(lines, line)
+	}
+	return "Likely problem(s):\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type namespaceFieldDiff struct {
+	Path string `json:"path"`
+	A    any    `json:"a,omitempty"`
+	B    any    `json:"b,omitempty"`
+}
+
+type namespaceObjectDiff struct {
+	Name string               `json:"name"`
+	Diff []namespaceFieldDiff `json:"diff"`
+}
+
+// K8sDiffNamespaces compares one resource kind across two namespaces, e.g.
+// staging vs prod before promoting config. Objects are matched by name and
+// normalized like k8s_export (status, server metadata, allocated values and
+// API defaults dropped) with the namespace removed, so only differences a user
+// wrote remain. Objects every namespace gets automatically (the default
+// ServiceAccount, kube-root-ca.crt, service account token Secrets) are skipped.
+// Secret values are compared but never printed.
+//
+// Args:
+// - ns_a, ns_b, resource_type (required)
+// - label_selector: only objects matching it
+func K8sDiffNamespaces(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	nsA := getStringArg(args, "ns_a")
+	nsB := getStringArg(args, "ns_b")
+	resourceType := getStringArg(args, "resource_type", "resource")
+	selector := getStringArg(args, "label_selector", "selector")
+
+	if strings.TrimSpace(nsA) == "" {
+		return textErrorResult("ns_a is required"), nil, nil
+	}
+	if strings.TrimSpace(nsB) == "" {
+		return textErrorResult("ns_b is required"), nil, nil
+	}
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
+	}
+	if !namespaced {
+		return textErrorResult(fmt.Sprintf("Error: %s is cluster-scoped", gvr.Resource)), nil, nil
+	}
+
+	load := func(ns string) (map[string]*unstructured.Unstructured, error) {
+		list, err := dyn.Resource(gvr).Namespace(ns).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return nil, err
+		}
+		out := map[string]*unstructured.Unstructured{}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if isNamespaceBuiltin(obj) {
+				continue
+			}
+			exportClean(obj)
+			unstructured.RemoveNestedField(obj.Object, "metadata", "namespace")
+			out[obj.GetName()] = obj
+		}
+		return out, nil
+	}
+	a, err := load(nsA)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	b, err := load(nsB)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	onlyA, onlyB := []string{}, []string{}
+	changed := []namespaceObjectDiff{}
+	identical := 0
+	for name, objA := range a {
+		objB, ok := b[name]
+		if !ok {
+			onlyA = append(onlyA, name)
+			continue
+		}
+		diffs := diffObjects(objA, objB)
+		if len(diffs) == 0 {
+			identical++
+			continue
+		}
+		changed = append(changed, namespaceObjectDiff{Name: name, Diff: diffs})
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			onlyB = append(onlyB, name)
+		}
+	}
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Name < changed[j].Name })
+
+	out, _ := json.MarshalIndent(map[string]any{
+		"resource":  gvr.Resource,
+		"ns_a":      nsA,
+		"ns_b":      nsB,
+		"only_in_a": onlyA,
+		"only_in_b": onlyB,
+		"changed":   changed,
+		"identical": identical,
+	}, "", "  ")
+	return textOKResult(string(out)), nil, nil
+}
+
+// diffObjects compares two normalized objects leaf by leaf (see flattenObject).
+func diffObjects(a, b *unstructured.Unstructured) []namespaceFieldDiff {
+	leavesA, leavesB := map[string]any{}, map[string]any{}
+	flattenObject(a.Object, "", leavesA, map[string]bool{})
+	flattenObject(b.Object, "", leavesB, map[string]bool{})
+	secret := strings.EqualFold(a.GetKind(), "Secret")
+
+	diffs := []namespaceFieldDiff{}
+	for p, va := range leavesA {
+		vb, ok := leavesB[p]
+		if ok && fmtAny(va) == fmtAny(vb) {
+			continue
+		}
+		d := namespaceFieldDiff{Path: p, A: va}
+		if ok {
+			d.B = vb
+		}
+		diffs = append(diffs, d)
+	}
+	for p, vb := range leavesB {
+		if _, ok := leavesA[p]; !ok {
+			diffs = append(diffs, namespaceFieldDiff{Path: p, B: vb})
+		}
+	}
+	for i := range diffs {
+		if secret && (strings.HasPrefix(diffs[i].Path, ".data.") || strings.HasPrefix(diffs[i].Path, ".stringData.")) {
+			if diffs[i].A != nil {
+				diffs[i].A = "<redacted>"
+			}
+			if diffs[i].B != nil {
+				diffs[i].B = "<redacted>"
+			}
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs
+}
+
+// isNamespaceBuiltin reports objects the control plane creates in every namespace.
+func isNamespaceBuiltin(obj *unstructured.Unstructured) bool {
+	switch obj.GetKind() {
+	case "ServiceAccount":
+		return obj.GetName() == "default"
+	case "ConfigMap":
+		return obj.GetName() == "kube-root-ca.crt"
+	case "Secret":
+		t, _, _ := unstructured.NestedString(obj.Object, "type")
+		return t == "kubernetes.io/service-account-token"
+	}
+	return false
+}
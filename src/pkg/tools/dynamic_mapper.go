@@ -10,9 +10,8 @@ import (
 )
 
 var (
-	restMapperOnce sync.Once
-	restMapper     meta.RESTMapper
-	restMapperErr  error
+	restMapperMu sync.Mutex
+	restMapper   meta.RESTMapper
 )
 
 // GetDynamicClient is a small exported wrapper used by create/apply.
@@ -23,15 +22,25 @@ func GetDynamicClient() (dynamic.Interface, error) {
 
 // GetRESTMapper returns a cached RESTMapper built from discovery.
 // This enables mapping GVK -> GVR for dynamic create/apply.
+// A failure is not cached, so the mapper is built once the cluster is reachable.
 func GetRESTMapper() (meta.RESTMapper, error) {
-	restMapperOnce.Do(func() {
-		disc, err := getDiscovery()
-		if err != nil {
-			restMapperErr = err
-			return
-		}
+	disc, err := getDiscovery()
+	if err != nil {
+		return nil, err
+	}
+
+	restMapperMu.Lock()
+	defer restMapperMu.Unlock()
+	if restMapper == nil {
 		cache := memory.NewMemCacheClient(disc)
 		restMapper = restmapper.NewDeferredDiscoveryRESTMapper(cache)
-	})
-	return restMapper, restMapperErr
+	}
+	return restMapper, nil
+}
+
+// resetRESTMapper drops the cached mapper when the clients are rebuilt.
+func resetRESTMapper() {
+	restMapperMu.Lock()
+	restMapper = nil
+	restMapperMu.Unlock()
 }
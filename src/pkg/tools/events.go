@@ -18,6 +18,10 @@ import (
 )
 
 // K8sEvents ports events.py k8s_events(...)
+// Extra args (list mode):
+// - since: drop events whose last time is older than this (e.g. 30m, 2h, 1d or an RFC3339 timestamp)
+// - limit: page size; the output becomes {events, continue} and continue fetches the next page
+// - continue: token from a previous page (same filters required)
 func K8sEvents(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	namespace, _ := args["namespace"].(string)
 	allNamespaces := boolFromArgs(args, "all_namespaces", false)
@@ -26,10 +30,24 @@ func K8sEvents(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any)
 	resourceName, _ := args["resource_name"].(string)
 	sortBy, _ := args["sort_by"].(string)
 	watchMode := boolFromArgs(args, "watch", false)
+	limit := intFromArgsDefault(args, "limit", 0)
+	continueToken := getStringArg(args, "continue")
+
+	var sinceCutoff time.Time
+	if since := getStringArg(args, "since"); strings.TrimSpace(since) != "" {
+		secs := parseSinceSeconds(since)
+		if secs == nil {
+			return textErrorResult(fmt.Sprintf("Error: invalid since %q (expected e.g. 30s, 5m, 2h, 1d or an RFC3339 timestamp)", since)), nil, nil
+		}
+		sinceCutoff = time.Now().Add(-time.Duration(*secs) * time.Second)
+	}
+	if limit < 0 {
+		return textErrorResult("Error: limit must not be negative"), nil, nil
+	}
 
 	// Default namespace like python
 	if !allNamespaces && namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	cs, err := getClient()
@@ -41,6 +59,11 @@ func K8sEvents(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any)
 	apiFieldSelector := strings.TrimSpace(fieldSelector)
 	if resourceType != "" && resourceName != "" {
 		kind := kindFromResourceType(resourceType)
+		if disc, err := getDiscovery(); err == nil {
+			if gvr, _, found := findGVR(disc, resourceType); found {
+				kind = kindForGVR(gvr)
+			}
+		}
 		resourceSel := fmt.Sprintf("involvedObject.kind=%s,involvedObject.name=%s", kind, resourceName)
 		if apiFieldSelector != "" {
 			apiFieldSelector = apiFieldSelector + "," + resourceSel
@@ -50,13 +73,78 @@ func K8sEvents(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any)
 	}
 
 	if watchMode {
-		return k8sEventsWatch(ctx, cs, namespace, allNamespaces, apiFieldSelector)
+		return k8sEventsWatch(ctx, cs, namespace, allNamespaces, apiFieldSelector, 10*time.Second)
+	}
+
+	lctx, cancel := toolContext(ctx)
+	defer cancel()
+	page := eventPage{limit: int64(limit), continueToken: continueToken, since: sinceCutoff}
+	return k8sEventsList(lctx, cs, namespace, allNamespaces, apiFieldSelector, sortBy, page)
+}
+
+// K8sObjectEventsWatch is the live event view for one object, e.g. a pod
+// or deployment during a rollout: the object's current events, then new ones
+// as they arrive until timeout. The kind comes from the REST mapper, so short
+// names like "deploy" select the right involvedObject.kind.
+//
+// Args:
+// - resource_type, name (required); namespace defaults to defaultNamespace()
+// - timeout seconds, default 60, max 600
+func K8sObjectEventsWatch(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType := getStringArg(args, "resource_type", "resource")
+	name := getStringArg(args, "name", "resource_name")
+	namespace := getStringArg(args, "namespace")
+	timeout := intFromArgsDefault(args, "timeout", 60)
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if timeout <= 0 {
+		timeout = 60
+	}
+	if timeout > 600 {
+		timeout = 600
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
 	}
 
-	return k8sEventsList(ctx, cs, namespace, allNamespaces, apiFieldSelector, sortBy)
+	selector := fmt.Sprintf("involvedObject.kind=%s,involvedObject.name=%s", kindForGVR(gvr), name)
+	if namespaced {
+		if namespace == "" {
+			namespace = defaultNamespace(ctx)
+		}
+		selector += ",involvedObject.namespace=" + namespace
+	} else {
+		// Events about cluster-scoped objects usually land in "default";
+		// search every namespace rather than guess.
+		namespace = metav1.NamespaceAll
+	}
+	return k8sEventsWatch(ctx, cs, namespace, !namespaced, selector, time.Duration(timeout)*time.Second)
+}
+
+// eventPage bounds an event list: a server-side page (limit/continue) and a
+// client-side time window applied before sorting and marshaling.
+type eventPage struct {
+	limit         int64
+	continueToken string
+	since         time.Time
 }
 
-func k8sEventsList(ctx context.Context, cs *kubernetes.Clientset, namespace string, allNamespaces bool, fieldSelector string, sortBy string) (*mcp.CallToolResult, any, error) {
+func k8sEventsList(ctx context.Context, cs *kubernetes.Clientset, namespace string, allNamespaces bool, fieldSelector string, sortBy string, page eventPage) (*mcp.CallToolResult, any, error) {
 	evNS := namespace
 	if allNamespaces {
 		evNS = metav1.NamespaceAll
@@ -64,6 +152,8 @@ func k8sEventsList(ctx context.Context, cs *kubernetes.Clientset, namespace stri
 
 	evs, err := cs.CoreV1().Events(evNS).List(ctx, metav1.ListOptions{
 		FieldSelector: fieldSelector,
+		Limit:         page.limit,
+		Continue:      page.continueToken,
 	})
 	if err != nil {
 		return textErrorResult("Error:\n" + err.Error()), nil, nil
@@ -71,6 +161,9 @@ func k8sEventsList(ctx context.Context, cs *kubernetes.Clientset, namespace stri
 
 	items := make([]map[string]any, 0, len(evs.Items))
 	for _, e := range evs.Items {
+		if !page.since.IsZero() && eventTime(&e).Before(page.since) {
+			continue
+		}
 		m := map[string]any{
 			"type":    e.Type,
 			"reason":  e.Reason,
@@ -92,13 +185,25 @@ func k8sEventsList(ctx context.Context, cs *kubernetes.Clientset, namespace stri
 
 	applyEventSort(items, sortBy)
 
+	if page.limit > 0 || page.continueToken != "" {
+		out := map[string]any{"events": items}
+		if evs.Continue != "" {
+			out["continue"] = evs.Continue
+		}
+		if evs.RemainingItemCount != nil {
+			out["remaining_item_count"] = *evs.RemainingItemCount
+		}
+		b, _ := json.MarshalIndent(out, "", "  ")
+		return textOKResult(string(b)), nil, nil
+	}
 	b, _ := json.MarshalIndent(items, "", "  ")
 	return textOKResult(string(b)), nil, nil
 }
 
-func k8sEventsWatch(ctx context.Context, cs *kubernetes.Clientset, namespace string, allNamespaces bool, fieldSelector string) (*mcp.CallToolResult, any, error) {
-	// Match python: watch up to ~10 seconds, 1MB cap
-	wctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+// k8sEventsWatch prints the events matching fieldSelector, then the ones that
+// arrive until timeout, capped at streamOutputCap.
+func k8sEventsWatch(ctx context.Context, cs *kubernetes.Clientset, namespace string, allNamespaces bool, fieldSelector string, timeout time.Duration) (*mcp.CallToolResult, any, error) {
+	wctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	evNS := namespace
@@ -114,14 +219,14 @@ func k8sEventsWatch(ctx context.Context, cs *kubernetes.Clientset, namespace str
 		return textErrorResult("Error:\n" + err.Error()), nil, nil
 	}
 
-	const maxBytes = 1024 * 1024
+	maxBytes := streamOutputCap()
 	var sb strings.Builder
 
 	// Print initial events
 	for _, e := range initial.Items {
 		line := formatEventLine(&e, "")
 		if sb.Len()+len(line) > maxBytes {
-			sb.WriteString("\n... event output truncated ...\n")
+			sb.WriteString(truncatedMarker(-1))
 			return textOKResult(sb.String()), nil, nil
 		}
 		sb.WriteString(line)
@@ -160,7 +265,7 @@ func k8sEventsWatch(ctx context.Context, cs *kubernetes.Clientset, namespace str
 
 			line := formatEventLine(obj, string(ev.Type))
 			if sb.Len()+len(line) > maxBytes {
-				sb.WriteString("\n... event output truncated ...\n")
+				sb.WriteString(truncatedMarker(-1))
 				return textOKResult(sb.String()), nil, nil
 			}
 			sb.WriteString(line)
@@ -257,20 +362,22 @@ func formatEventLine(e *v1.Event, watchType string) string {
 }
 
 func eventTimestamp(e *v1.Event) string {
-	// Prefer last/first timestamps; fall back to creationTimestamp
-	if !e.LastTimestamp.Time.IsZero() {
-		return e.LastTimestamp.Time.UTC().Format(time.RFC3339)
-	}
-	if !e.EventTime.Time.IsZero() {
-		return e.EventTime.Time.UTC().Format(time.RFC3339)
-	}
-	if !e.FirstTimestamp.Time.IsZero() {
-		return e.FirstTimestamp.Time.UTC().Format(time.RFC3339)
+	t := eventTime(e)
+	if t.IsZero() {
+		return ""
 	}
-	if !e.CreationTimestamp.Time.IsZero() {
-		return e.CreationTimestamp.Time.UTC().Format(time.RFC3339)
+	return t.UTC().Format(time.RFC3339)
+}
+
+// eventTime is when the event last happened: last/event/first timestamp,
+// falling back to creationTimestamp.
+func eventTime(e *v1.Event) time.Time {
+	for _, t := range []time.Time{e.LastTimestamp.Time, e.EventTime.Time, e.FirstTimestamp.Time, e.CreationTimestamp.Time} {
+		if !t.IsZero() {
+			return t
+		}
 	}
-	return ""
+	return time.Time{}
 }
 
 func formatMetaTime(t metav1.Time) string {
@@ -0,0 +1,213 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// maxExecStderrBytes bounds the stderr kept for error messages.
+const maxExecStderrBytes = 64 * 1024
+
+// K8sExecCommand runs a command in a pod container and returns its stdout.
+// Output is capped while it streams: once max_bytes or max_lines is reached the
+// exec is cancelled and the truncation marker appended, so `cat` on a large
+// file or a never-ending command can't produce an unbounded result.
+//
+// Args:
+// - pod_name, command (required); command is a list or a shell string (run via /bin/sh -c)
+// - container: defaults to the pod's default container; namespace defaults to defaultNamespace()
+// - container_pattern: regex on container names instead of container; must match exactly one
+// - max_bytes: default and upper bound streamOutputCap() (1MB or --max-response-bytes)
+// - max_lines: 0 (default) means no line cap
+func K8sExecCommand(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	podName, _ := args["pod_name"].(string)
+	container, _ := args["container"].(string)
+	namespace, _ := args["namespace"].(string)
+	maxBytes := intFromArgsDefault(args, "max_bytes", 0)
+	maxLines := intFromArgsDefault(args, "max_lines", 0)
+	command := execCommandFromArgs(args)
+	containerRe, err := execContainerPattern(args, container)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	if strings.TrimSpace(podName) == "" {
+		return textErrorResult("pod_name is required"), nil, nil
+	}
+	if len(command) == 0 {
+		return textErrorResult("command is required"), nil, nil
+	}
+	if maxBytes < 0 || maxLines < 0 {
+		return textErrorResult("Error: max_bytes and max_lines must not be negative"), nil, nil
+	}
+	if limit := streamOutputCap(); maxBytes == 0 || maxBytes > limit {
+		maxBytes = limit
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	rc, err := getRestConfig()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	if containerRe != nil {
+		pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		if container, err = containerByPattern(pod, containerRe); err != nil {
+			return textErrorResult(err.Error()), nil, nil
+		}
+	} else {
+		container, err = defaultContainer(ctx, cs, namespace, podName, container)
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+	}
+
+	out, truncated, err := runCappedExec(ctx, cs, rc, namespace, podName, container, command, maxBytes, maxLines)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	if truncated {
+		out += truncatedMarker(-1)
+	}
+	return textOKResult(out), nil, nil
+}
+
+// execContainerPattern compiles the container_pattern arg, which replaces
+// container; nil when it is not given.
+func execContainerPattern(args map[string]any, container string) (*regexp.Regexp, error) {
+	pattern := getStringArg(args, "container_pattern")
+	if pattern == "" {
+		return nil, nil
+	}
+	if container != "" {
+		return nil, errors.New("Error: container cannot be combined with container_pattern")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("Error: invalid container_pattern %q: %v", pattern, err)
+	}
+	return re, nil
+}
+
+// containerByPattern resolves container_pattern to the one container of pod
+// it matches. A command runs in a single container, so no match or several
+// matches is an error listing the candidates.
+func containerByPattern(pod *v1.Pod, re *regexp.Regexp) (string, error) {
+	matches := matchContainers(pod, re)
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	all := strings.Join(matchContainers(pod, nil), ", ")
+	if len(matches) == 0 {
+		return "", fmt.Errorf("Error: no container in pod '%s' matches %q (containers: %s)", pod.Name, re.String(), all)
+	}
+	return "", fmt.Errorf("Error: container_pattern %q matches %d containers in pod '%s' (%s); it must match exactly one", re.String(), len(matches), pod.Name, strings.Join(matches, ", "))
+}
+
+// execCommandFromArgs reads the command arg: a list, or a shell string run
+// via /bin/sh -c.
+func execCommandFromArgs(args map[string]any) []string {
+	if c, ok := args["command"].(string); ok {
+		if strings.TrimSpace(c) == "" {
+			return nil
+		}
+		return []string{"/bin/sh", "-c", c}
+	}
+	return stringSliceFromArgs(args, "command")
+}
+
+// runCappedExec runs command in a container and returns its stdout, capped
+// by maxBytes/maxLines. When the cap is hit the exec is cancelled and
+// truncated is set; whatever the cancelled stream returned is ignored. The
+// error carries stderr and any partial stdout.
+func runCappedExec(ctx context.Context, cs *kubernetes.Clientset, rc *rest.Config, namespace, pod, container string, command []string, maxBytes, maxLines int) (string, bool, error) {
+	execCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stdout := &cappedWriter{maxBytes: maxBytes, maxLines: maxLines, onFull: cancel}
+	stderr := &cappedWriter{maxBytes: maxExecStderrBytes}
+
+	err := execPod(execCtx, cs, rc, namespace, pod, container, command, nil, stdout, stderr)
+	if stdout.truncated {
+		return stdout.buf.String(), true, nil
+	}
+	if err != nil {
+		msg := "Error: " + err.Error()
+		if s := strings.TrimSpace(stderr.buf.String()); s != "" {
+			msg += "\n" + s
+		}
+		if stdout.buf.Len() > 0 {
+			msg += "\nstdout:\n" + stdout.buf.String()
+		}
+		return "", false, errors.New(msg)
+	}
+	return stdout.buf.String(), false, nil
+}
+
+// cappedWriter keeps at most maxBytes bytes and maxLines lines (0 = no line
+// cap). Past the cap it drops input, sets truncated and calls onFull once so
+// the producer can be stopped.
+type cappedWriter struct {
+	buf       bytes.Buffer
+	maxBytes  int
+	maxLines  int
+	lines     int
+	truncated bool
+	onFull    func()
+}
+
+func (w *cappedWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if w.truncated {
+		return n, nil
+	}
+	if w.maxLines > 0 && w.lines >= w.maxLines && n > 0 {
+		w.truncate()
+		return n, nil
+	}
+
+	keep := p
+	if w.maxLines > 0 {
+		for i, b := range keep {
+			if b == '\n' {
+				w.lines++
+				if w.lines == w.maxLines {
+					keep = keep[:i+1]
+					break
+				}
+			}
+		}
+	}
+	if room := w.maxBytes - w.buf.Len(); len(keep) > room {
+		keep = keep[:room]
+	}
+	w.buf.Write(keep)
+	if len(keep) < n {
+		w.truncate()
+	}
+	return n, nil
+}
+
+func (w *cappedWriter) truncate() {
+	w.truncated = true
+	if w.onFull != nil {
+		w.onFull()
+	}
+}
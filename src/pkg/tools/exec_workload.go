@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// K8sExecWorkload runs a command in one ready pod of a Deployment,
+// StatefulSet or DaemonSet, picked through the workload's selector, so the
+// caller doesn't have to look up a pod name first. The result names the pod
+// that was used.
+//
+// Args:
+// - resource_type (deployment, statefulset, daemonset), name, command (required);
+// command is a list or a shell string (run via /bin/sh -c)
+// - container: defaults to the pod's default container; namespace defaults to defaultNamespace()
+// - container_pattern: regex on container names instead of container; must match exactly one
+// - max_bytes, max_lines: as for k8s_exec_command
+func K8sExecWorkload(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType := getStringArg(args, "resource_type")
+	name := getStringArg(args, "name", "resource_name")
+	namespace := getStringArg(args, "namespace")
+	container := getStringArg(args, "container")
+	maxBytes := intFromArgsDefault(args, "max_bytes", 0)
+	maxLines := intFromArgsDefault(args, "max_lines", 0)
+	command := execCommandFromArgs(args)
+	containerRe, err := execContainerPattern(args, container)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if len(command) == 0 {
+		return textErrorResult("command is required"), nil, nil
+	}
+	if maxBytes < 0 || maxLines < 0 {
+		return textErrorResult("Error: max_bytes and max_lines must not be negative"), nil, nil
+	}
+	if limit := streamOutputCap(); maxBytes == 0 || maxBytes > limit {
+		maxBytes = limit
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	rc, err := getRestConfig()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	selector, err := workloadSelector(ctx, cs, resourceType, name, namespace)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	pods, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	pod := pickReadyPod(pods.Items)
+	if pod == nil {
+		return textErrorResult(fmt.Sprintf("Error: %s/%s has no ready pod to exec into (%d pod(s) match %q)", strings.ToLower(resourceType), name, len(pods.Items), selector)), nil, nil
+	}
+	if containerRe != nil {
+		if container, err = containerByPattern(pod, containerRe); err != nil {
+			return textErrorResult(err.Error()), nil, nil
+		}
+	} else if container == "" {
+		container = podDefaultContainerName(pod)
+	}
+
+	stdout, truncated, err := runCappedExec(ctx, cs, rc, namespace, pod.Name, container, command, maxBytes, maxLines)
+	if err != nil {
+		return textErrorResult(fmt.Sprintf("pod: %s\n%s", pod.Name, err.Error())), nil, nil
+	}
+	if truncated {
+		stdout += truncatedMarker(-1)
+	}
+	b, _ := json.MarshalIndent(map[string]any{
+		"pod":       pod.Name,
+		"container": container,
+		"truncated": truncated,
+		"stdout":    stdout,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// pickReadyPod returns the oldest Ready pod that is not being deleted (ties
+// broken by name), so repeated calls keep landing on the same pod.
+func pickReadyPod(pods []v1.Pod) *v1.Pod {
+	var ready []*v1.Pod
+	for i := range pods {
+		p := &pods[i]
+		if p.DeletionTimestamp == nil && p.Status.Phase == v1.PodRunning && podReady(p) {
+			ready = append(ready, p)
+		}
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		ti, tj := ready[i].CreationTimestamp, ready[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return ready[i].Name < ready[j].Name
+	})
+	return ready[0]
+}
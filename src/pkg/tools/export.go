@@ -0,0 +1,277 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// K8sExport returns a live object as a clean YAML manifest that can be
+// committed and re-applied: status and server-populated metadata are dropped
+// (see stripServerFields), as are ownerReferences, allocated values (node
+// binding, cluster IPs, node ports, bound volume names) and fields that only
+// repeat an API default. Labels and annotations are kept except for the ones
+// the server or kubectl maintain.
+//
+// Args: resource_type, name (required); namespace defaults to defaultNamespace()
+func K8sExport(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType := getStringArg(args, "resource_type", "resource")
+	name := getStringArg(args, "name")
+	namespace := getStringArg(args, "namespace")
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
+	}
+
+	var obj *unstructured.Unstructured
+	if namespaced {
+		obj, err = dyn.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	} else {
+		obj, err = dyn.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	exportClean(obj)
+	b, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+	return textOKResult(string(b)), nil, nil
+}
+
+// exportClean strips obj down to what a user would have written.
+func exportClean(obj *unstructured.Unstructured) {
+	stripServerFields(obj.Object)
+	unstructured.RemoveNestedField(obj.Object, "metadata", "ownerReferences")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "generateName")
+	if ann := obj.GetAnnotations(); ann != nil {
+		for k := range ann {
+			if strings.HasPrefix(k, "pv.kubernetes.io/") || strings.HasPrefix(k, "volume.kubernetes.io/") || strings.HasPrefix(k, "volume.beta.kubernetes.io/") {
+				delete(ann, k)
+			}
+		}
+		if len(ann) == 0 {
+			unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+		} else {
+			obj.SetAnnotations(ann)
+		}
+	}
+
+	o := obj.Object
+	switch strings.ToLower(obj.GetKind()) {
+	case "deployment":
+		removeIfEqual(o, int64(600), "spec", "progressDeadlineSeconds")
+		removeIfEqual(o, int64(10), "spec", "revisionHistoryLimit")
+		if t, _, _ := unstructured.NestedString(o, "spec", "strategy", "type"); t == "RollingUpdate" {
+			su, _, _ := unstructured.NestedFieldNoCopy(o, "spec", "strategy", "rollingUpdate", "maxSurge")
+			mu, _, _ := unstructured.NestedFieldNoCopy(o, "spec", "strategy", "rollingUpdate", "maxUnavailable")
+			if fmtAny(su) == "25%" && fmtAny(mu) == "25%" {
+				unstructured.RemoveNestedField(o, "spec", "strategy")
+			}
+		}
+	case "statefulset":
+		removeIfEqual(o, "OrderedReady", "spec", "podManagementPolicy")
+		removeIfEqual(o, int64(10), "spec", "revisionHistoryLimit")
+		removeIfEqual(o, int64(0), "spec", "updateStrategy", "rollingUpdate", "partition")
+		pruneEmpty(o, "spec", "updateStrategy", "rollingUpdate")
+		removeIfEqual(o, "RollingUpdate", "spec", "updateStrategy", "type")
+		pruneEmpty(o, "spec", "updateStrategy")
+		removeIfEqual(o, "Retain", "spec", "persistentVolumeClaimRetentionPolicy", "whenDeleted")
+		removeIfEqual(o, "Retain", "spec", "persistentVolumeClaimRetentionPolicy", "whenScaled")
+		pruneEmpty(o, "spec", "persistentVolumeClaimRetentionPolicy")
+		templates, _, _ := unstructured.NestedSlice(o, "spec", "volumeClaimTemplates")
+		for _, t := range templates {
+			if m, ok := t.(map[string]any); ok {
+				delete(m, "status")
+				unstructured.RemoveNestedField(m, "metadata", "creationTimestamp")
+				removeIfEqual(m, "Filesystem", "spec", "volumeMode")
+			}
+		}
+		if len(templates) > 0 {
+			_ = unstructured.SetNestedSlice(o, templates, "spec", "volumeClaimTemplates")
+		}
+	case "daemonset":
+		removeIfEqual(o, int64(10), "spec", "revisionHistoryLimit")
+	case "service":
+		// A headless service's clusterIP: None is part of its definition.
+		if ip, _, _ := unstructured.NestedString(o, "spec", "clusterIP"); ip != "None" {
+			unstructured.RemoveNestedField(o, "spec", "clusterIP")
+			unstructured.RemoveNestedField(o, "spec", "clusterIPs")
+		}
+		unstructured.RemoveNestedField(o, "spec", "ipFamilies")
+		unstructured.RemoveNestedField(o, "spec", "healthCheckNodePort")
+		removeIfEqual(o, "SingleStack", "spec", "ipFamilyPolicy")
+		removeIfEqual(o, "None", "spec", "sessionAffinity")
+		removeIfEqual(o, "Cluster", "spec", "internalTrafficPolicy")
+		removeIfEqual(o, "Cluster", "spec", "externalTrafficPolicy")
+		removeIfEqual(o, "ClusterIP", "spec", "type")
+		ports, _, _ := unstructured.NestedSlice(o, "spec", "ports")
+		for _, p := range ports {
+			if m, ok := p.(map[string]any); ok {
+				delete(m, "nodePort")
+				if m["protocol"] == "TCP" {
+					delete(m, "protocol")
+				}
+				if fmtAny(m["targetPort"]) == fmtAny(m["port"]) {
+					delete(m, "targetPort")
+				}
+			}
+		}
+		if len(ports) > 0 {
+			_ = unstructured.SetNestedSlice(o, ports, "spec", "ports")
+		}
+	case "persistentvolumeclaim":
+		unstructured.RemoveNestedField(o, "spec", "volumeName")
+		removeIfEqual(o, "Filesystem", "spec", "volumeMode")
+	}
+
+	if path := podSpecPathForKind(obj.GetKind()); path != nil {
+		if len(path) > 1 {
+			// the template's metadata.creationTimestamp: null
+			tmplMeta := append(append([]string{}, path[:len(path)-1]...), "metadata")
+			unstructured.RemoveNestedField(o, append(tmplMeta, "creationTimestamp")...)
+			pruneEmpty(o, tmplMeta...)
+		}
+		if spec, found, _ := unstructured.NestedMap(o, path...); found {
+			cleanPodSpec(spec)
+			_ = unstructured.SetNestedMap(o, spec, path...)
+		}
+	}
+}
+
+// cleanPodSpec removes pod spec fields that are allocated or equal the API default.
+func cleanPodSpec(spec map[string]any) {
+	delete(spec, "nodeName")
+	delete(spec, "priority") // admission copies it from priorityClassName
+	if spec["serviceAccount"] == spec["serviceAccountName"] {
+		delete(spec, "serviceAccount")
+	}
+	if spec["serviceAccountName"] == "default" {
+		delete(spec, "serviceAccountName")
+	}
+	removeIfEqual(spec, "ClusterFirst", "dnsPolicy")
+	removeIfEqual(spec, "Always", "restartPolicy")
+	removeIfEqual(spec, "default-scheduler", "schedulerName")
+	removeIfEqual(spec, int64(30), "terminationGracePeriodSeconds")
+	removeIfEqual(spec, true, "enableServiceLinks")
+	removeIfEqual(spec, "PreemptLowerPriority", "preemptionPolicy")
+	pruneEmpty(spec, "securityContext")
+
+	// The not-ready/unreachable tolerations are added by admission.
+	if tols, ok := spec["tolerations"].([]any); ok {
+		kept := tols[:0]
+		for _, t := range tols {
+			m, _ := t.(map[string]any)
+			key := fmtAny(m["key"])
+			if (key == "node.kubernetes.io/not-ready" || key == "node.kubernetes.io/unreachable") && fmtAny(m["tolerationSeconds"]) == "300" {
+				continue
+			}
+			kept = append(kept, t)
+		}
+		if len(kept) == 0 {
+			delete(spec, "tolerations")
+		} else {
+			spec["tolerations"] = kept
+		}
+	}
+
+	if vols, ok := spec["volumes"].([]any); ok {
+		for _, v := range vols {
+			m, _ := v.(map[string]any)
+			for _, src := range []string{"configMap", "secret"} {
+				removeIfEqual(m, int64(420), src, "defaultMode")
+			}
+		}
+	}
+
+	for _, key := range []string{"containers", "initContainers"} {
+		list, _ := spec[key].([]any)
+		for _, c := range list {
+			if m, ok := c.(map[string]any); ok {
+				cleanContainer(m)
+			}
+		}
+	}
+}
+
+func cleanContainer(c map[string]any) {
+	removeIfEqual(c, "/dev/termination-log", "terminationMessagePath")
+	removeIfEqual(c, "File", "terminationMessagePolicy")
+	pruneEmpty(c, "resources")
+	if image := fmtAny(c["image"]); image != "" {
+		// IfNotPresent, or Always for :latest / untagged images.
+		def := "IfNotPresent"
+		ref := image
+		if strings.Contains(ref, "@") {
+			ref = "" // pinned by digest
+		} else if i := strings.LastIndex(ref, "/"); i >= 0 {
+			ref = ref[i+1:]
+		}
+		if ref != "" && (!strings.Contains(ref, ":") || strings.HasSuffix(ref, ":latest")) {
+			def = "Always"
+		}
+		removeIfEqual(c, def, "imagePullPolicy")
+	}
+	if ports, ok := c["ports"].([]any); ok {
+		for _, p := range ports {
+			if m, ok := p.(map[string]any); ok && m["protocol"] == "TCP" {
+				delete(m, "protocol")
+			}
+		}
+	}
+	for _, probe := range []string{"livenessProbe", "readinessProbe", "startupProbe"} {
+		p, ok := c[probe].(map[string]any)
+		if !ok {
+			continue
+		}
+		removeIfEqual(p, int64(1), "timeoutSeconds")
+		removeIfEqual(p, int64(10), "periodSeconds")
+		removeIfEqual(p, int64(1), "successThreshold")
+		removeIfEqual(p, int64(3), "failureThreshold")
+		removeIfEqual(p, "HTTP", "httpGet", "scheme")
+	}
+}
+
+// removeIfEqual deletes the field at path when it holds want.
+func removeIfEqual(obj map[string]any, want any, path ...string) {
+	v, found, err := unstructured.NestedFieldNoCopy(obj, path...)
+	if err != nil || !found {
+		return
+	}
+	if v == want || fmtAny(v) == fmtAny(want) {
+		unstructured.RemoveNestedField(obj, path...)
+	}
+}
+
+// pruneEmpty deletes the map at path when it has no fields left.
+func pruneEmpty(obj map[string]any, path ...string) {
+	if m, found, _ := unstructured.NestedMap(obj, path...); found && len(m) == 0 {
+		unstructured.RemoveNestedField(obj, path...)
+	}
+}
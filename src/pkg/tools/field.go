@@ -0,0 +1,251 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/metadata"
+)
+
+// K8sGetField fetches one object and returns only the value at field_path, so a
+// caller that needs a single value doesn't pay for the whole object.
+//
+// Paths are dotted with bracketed list indexes, e.g. "spec.replicas" or
+// "status.loadBalancer.ingress[0].ip". Keys containing dots can be quoted:
+// metadata.annotations['app.kubernetes.io/name'].
+//
+// Args: resource_type, name, field_path required; namespace defaults to defaultNamespace()
+func K8sGetField(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType, _ := args["resource_type"].(string)
+	name, _ := args["name"].(string)
+	namespace, _ := args["namespace"].(string)
+	fieldPath, _ := args["field_path"].(string)
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if strings.TrimSpace(fieldPath) == "" {
+		return textErrorResult("field_path is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	path, err := parseFieldPath(fieldPath)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
+	}
+
+	var obj *unstructured.Unstructured
+	if namespaced {
+		obj, err = dyn.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	} else {
+		obj, err = dyn.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	value, err := lookupFieldPath(obj.Object, path)
+	if err != nil {
+		return textErrorResult(fmt.Sprintf("Error: %s: %v", fieldPath, err)), nil, nil
+	}
+
+	b, _ := json.MarshalIndent(map[string]any{
+		"field_path": fieldPath,
+		"value":      value,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// parseFieldPath splits "a.b[0]['c.d']" into segments; list indexes are ints,
+// map keys are strings. A leading "." or "{.…}" (jsonpath habit) is tolerated.
+func parseFieldPath(p string) ([]any, error) {
+	p = strings.TrimSpace(p)
+	p = strings.TrimSuffix(strings.TrimPrefix(p, "{"), "}")
+	p = strings.TrimPrefix(p, ".")
+
+	var segs []any
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			segs = append(segs, cur.String())
+			cur.Reset()
+		}
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '.':
+			flush()
+		case '[':
+			flush()
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '[' in field path %q", p)
+			}
+			inner := strings.TrimSpace(p[i+1 : i+end])
+			i += end
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				segs = append(segs, inner[1:len(inner)-1])
+				continue
+			}
+			idx, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index [%s] in field path %q", inner, p)
+			}
+			segs = append(segs, idx)
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	flush()
+
+	if len(segs) == 0 {
+		return nil, fmt.Errorf("empty field path")
+	}
+	return segs, nil
+}
+
+// lookupFieldPath walks obj without copying; negative indexes count from the end.
+func lookupFieldPath(obj any, path []any) (any, error) {
+	cur := obj
+	for i, seg := range path {
+		switch s := seg.(type) {
+		case string:
+			m, ok := cur.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s is not an object", fieldPathString(path[:i]))
+			}
+			v, ok := m[s]
+			if !ok {
+				return nil, fmt.Errorf("field %s not found", fieldPathString(path[:i+1]))
+			}
+			cur = v
+		case int:
+			l, ok := cur.([]any)
+			if !ok {
+				return nil, fmt.Errorf("%s is not a list", fieldPathString(path[:i]))
+			}
+			idx := s
+			if idx < 0 {
+				idx += len(l)
+			}
+			if idx < 0 || idx >= len(l) {
+				return nil, fmt.Errorf("index %d out of range at %s (length %d)", s, fieldPathString(path[:i]), len(l))
+			}
+			cur = l[idx]
+		}
+	}
+	return cur, nil
+}
+
+func fieldPathString(path []any) string {
+	if len(path) == 0 {
+		return "the object"
+	}
+	var sb strings.Builder
+	for _, seg := range path {
+		switch s := seg.(type) {
+		case int:
+			fmt.Fprintf(&sb, "[%d]", s)
+		case string:
+			if strings.Contains(s, ".") {
+				fmt.Fprintf(&sb, "['%s']", s)
+				continue
+			}
+			if sb.Len() > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(s)
+		}
+	}
+	return sb.String()
+}
+
+// K8sExists reports whether an object exists without treating NotFound as an
+// error. It uses a metadata-only (PartialObjectMetadata) get so large objects
+// are not transferred.
+//
+// Args: resource_type, name required; namespace defaults to defaultNamespace()
+func K8sExists(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType, _ := args["resource_type"].(string)
+	name, _ := args["name"].(string)
+	namespace, _ := args["namespace"].(string)
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	rc, err := getRestConfig()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	mc, err := metadata.NewForConfig(rc)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
+	}
+
+	var obj *metav1.PartialObjectMetadata
+	if namespaced {
+		obj, err = mc.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	} else {
+		obj, err = mc.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
+	}
+
+	out := map[string]any{"exists": false}
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	default:
+		out["exists"] = true
+		out["resource_version"] = obj.ResourceVersion
+		out["uid"] = string(obj.UID)
+		if obj.DeletionTimestamp != nil {
+			out["terminating"] = true
+		}
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
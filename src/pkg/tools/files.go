@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	defaultReadFileBytes = 1024 * 1024
+	maxReadFileBytes     = 8 * 1024 * 1024
+)
+
+// K8sReadFile returns one file from a running container, a lighter alternative to
+// k8s_cp when the caller just wants to look at a config file.
+//
+// Non-UTF-8 content (or content containing NUL bytes) is returned base64-encoded.
+//
+// Args:
+// - pod_name, path required; namespace defaults to defaultNamespace()
+// - container defaults to the pod's default container (see podDefaultContainerName)
+// - max_bytes default 1MiB, max 8MiB; larger files are truncated
+func K8sReadFile(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	podName, _ := args["pod_name"].(string)
+	path, _ := args["path"].(string)
+	container, _ := args["container"].(string)
+	namespace, _ := args["namespace"].(string)
+
+	if strings.TrimSpace(podName) == "" {
+		return textErrorResult("pod_name is required"), nil, nil
+	}
+	if strings.TrimSpace(path) == "" {
+		return textErrorResult("path is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	maxBytes := intFromArgsDefault(args, "max_bytes", defaultReadFileBytes)
+	if maxBytes <= 0 {
+		maxBytes = defaultReadFileBytes
+	}
+	if maxBytes > maxReadFileBytes {
+		maxBytes = maxReadFileBytes
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	rc, err := getRestConfig()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	container, err = defaultContainer(ctx, cs, namespace, podName, container)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	// Read one byte past the cap so truncation can be detected without a separate stat.
+	cmd := fmt.Sprintf(`[ -f %[1]s ] || { echo "not a regular file:" %[1]s >&2; exit 1; }; head -c %[2]d %[1]s`,
+		shellQuote(path), maxBytes+1)
+	data, err := execReadAll(ctx, cs, rc, namespace, podName, container, []string{"/bin/sh", "-c", cmd}, nil)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	truncated := len(data) > maxBytes
+	if truncated {
+		data = trimPartialRune(data[:maxBytes])
+	}
+
+	out := map[string]any{
+		"pod":       podName,
+		"container": container,
+		"path":      path,
+		"bytes":     len(data),
+		"truncated": truncated,
+	}
+	if utf8.Valid(data) && !bytes.Contains(data, []byte{0}) {
+		out["encoding"] = "utf-8"
+		out["content"] = string(data)
+	} else {
+		out["encoding"] = "base64"
+		out["content"] = base64.StdEncoding.EncodeToString(data)
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// trimPartialRune drops a UTF-8 sequence cut off at the end of data, so the cut
+// doesn't turn a text file into "binary". Data that is not valid UTF-8 apart
+// from such a tail is returned unchanged.
+func trimPartialRune(data []byte) []byte {
+	if utf8.Valid(data) {
+		return data
+	}
+	for i := 1; i < utf8.UTFMax && i <= len(data); i++ {
+		if utf8.Valid(data[:len(data)-i]) {
+			return data[:len(data)-i]
+		}
+	}
+	return data
+}
+
+// K8sWriteFile writes content to a file inside a running container through
+// `cat > path` on stdin, creating parent directories. It is meant for small
+// config/script files; use k8s_cp for directories.
+//
+// Args:
+// - pod_name, path, content required; namespace defaults to defaultNamespace()
+// - container defaults to the pod's default container (see podDefaultContainerName)
+// - content_encoding "utf-8" (default) or "base64" for binary payloads
+func K8sWriteFile(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	podName, _ := args["pod_name"].(string)
+	path, _ := args["path"].(string)
+	container, _ := args["container"].(string)
+	namespace, _ := args["namespace"].(string)
+	content, hasContent := args["content"].(string)
+	encoding := strings.ToLower(getStringArg(args, "content_encoding"))
+
+	if strings.TrimSpace(podName) == "" {
+		return textErrorResult("pod_name is required"), nil, nil
+	}
+	if strings.TrimSpace(path) == "" {
+		return textErrorResult("path is required"), nil, nil
+	}
+	if !hasContent {
+		return textErrorResult("content is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	var data []byte
+	switch encoding {
+	case "", "utf-8", "utf8", "text":
+		data = []byte(content)
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(content))
+		if err != nil {
+			return textErrorResult("Error: content is not valid base64: " + err.Error()), nil, nil
+		}
+		data = decoded
+	default:
+		return textErrorResult(fmt.Sprintf("Error: unsupported content_encoding '%s' (expected utf-8 or base64)", encoding)), nil, nil
+	}
+	if len(data) > maxReadFileBytes {
+		return textErrorResult(fmt.Sprintf("Error: content is %d bytes; k8s_write_file accepts at most %d (use k8s_cp)", len(data), maxReadFileBytes)), nil, nil
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	rc, err := getRestConfig()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	container, err = defaultContainer(ctx, cs, namespace, podName, container)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	cmd := fmt.Sprintf(`mkdir -p "$(dirname %[1]s)" && cat > %[1]s`, shellQuote(path))
+	if err := execWriteAll(ctx, cs, rc, namespace, podName, container, []string{"/bin/sh", "-c", cmd}, bytes.NewReader(data)); err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	return textOKResult(fmt.Sprintf("Successfully wrote %d bytes to %s:%s (container %s)", len(data), podName, path, container)), nil, nil
+}
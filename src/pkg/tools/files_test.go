@@ -0,0 +1,26 @@
+package tools
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTrimPartialRune(t *testing.T) {
+	euro := []byte("€") // 3 bytes
+	tests := []struct {
+		name string
+		in   []byte
+		want []byte
+	}{
+		{"ascii", []byte("hello"), []byte("hello")},
+		{"whole rune", append([]byte("a"), euro...), append([]byte("a"), euro...)},
+		{"cut rune", append([]byte("a"), euro[:2]...), []byte("a")},
+		{"binary", []byte{0xff, 0xfe, 0x00, 0x01, 0xc3}, []byte{0xff, 0xfe, 0x00, 0x01, 0xc3}},
+		{"binary with high tail", []byte{0x89, 'P', 'N', 'G', 0xe2, 0x82}, []byte{0x89, 'P', 'N', 'G', 0xe2, 0x82}},
+	}
+	for _, tt := range tests {
+		if got := trimPartialRune(tt.in); !bytes.Equal(got, tt.want) {
+			t.Errorf("%s: trimPartialRune(%x) = %x, want %x", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
@@ -3,10 +3,13 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -20,10 +23,21 @@ import (
 // - resource can match plural name, singularName, or shortNames
 // - name="" means list
 // - namespace="" means all namespaces (for namespaced resources)
-// - for namespaced GET with no namespace specified, default "default"
+// - for namespaced GET with no namespace specified, use defaultNamespace()
+// - lists: sort_by "name", "created" or a field path (e.g. status.startTime); reverse (bool)
+// - output: "json" (default), "table" or "wide" for the server's kubectl-style columns (unsorted); wide adds a computed QOS column for pods
+// - owner: "Kind/name" (e.g. ReplicaSet/my-rs); keep only list items with that ownerReference
+// - version: read through this API version instead of the preferred one (must be served)
+// - clean (bool): drop metadata.managedFields from json output; clean_status (bool) also drops status
 func K8sGet(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	resource, _ := args["resource"].(string)
 	name, _ := args["name"].(string)
+	sortBy := getStringArg(args, "sort_by")
+	reverse := boolFromArgs(args, "reverse", false)
+	output := strings.ToLower(getStringArg(args, "output"))
+	ownerSpec := getStringArg(args, "owner")
+	clean := boolFromArgs(args, "clean", false)
+	cleanStatus := boolFromArgs(args, "clean_status", false)
 
 	// namespace may come as string or may be missing
 	namespace, _ := args["namespace"].(string)
@@ -31,6 +45,30 @@ func K8sGet(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*
 	if strings.TrimSpace(resource) == "" {
 		return textErrorResult("resource is required"), nil, nil
 	}
+	switch output {
+	case "", "json", "table", "wide":
+	default:
+		return textErrorResult(fmt.Sprintf("Error: unsupported output '%s' (expected json, table or wide)", output)), nil, nil
+	}
+	var owner *ownerFilter
+	if ownerSpec != "" {
+		o, err := parseOwnerFilter(ownerSpec)
+		if err != nil {
+			return textErrorResult("Error: " + err.Error()), nil, nil
+		}
+		if name != "" || output == "table" || output == "wide" {
+			return textErrorResult("Error: owner only applies to json lists (no name, no table output)"), nil, nil
+		}
+		owner = o
+	}
+	var sortPath []any
+	if sortBy != "" && sortBy != "name" && sortBy != "created" {
+		p, err := parseFieldPath(sortBy)
+		if err != nil {
+			return textErrorResult(fmt.Sprintf("Error: invalid sort_by %q: %v", sortBy, err)), nil, nil
+		}
+		sortPath = p
+	}
 
 	disc, err := getDiscovery()
 	if err != nil {
@@ -45,59 +83,214 @@ func K8sGet(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*
 	if !found {
 		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resource)), nil, nil
 	}
+	if gvr, err = withVersion(disc, gvr, getStringArg(args, "version")); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	if output == "table" || output == "wide" {
+		ns := namespace
+		if namespaced && name != "" && ns == "" {
+			ns = defaultNamespace(ctx)
+		}
+		text, err := getTable(ctx, disc, gvr, namespaced, ns, name, output == "wide")
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		return textOKResult(text), nil, nil
+	}
 
 	ri := dyn.Resource(gvr)
 
+	var list *unstructured.UnstructuredList
+
 	// Mirror Python behavior
 	if namespaced {
 		if name != "" {
 			ns := namespace
 			if ns == "" {
-				ns = "default"
+				ns = defaultNamespace(ctx)
 			}
 			obj, err := ri.Namespace(ns).Get(ctx, name, metav1.GetOptions{})
 			if err != nil {
 				return textErrorResult(formatK8sErr(err)), nil, nil
 			}
+			cleanGetObject(obj, clean, cleanStatus)
 			return marshalUnstructured(obj), nil, nil
 		}
 
-		// list
-		if namespace == "" {
-			// all namespaces
-			list, err := ri.Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		// list; namespace "" means all namespaces
+		list, err = ri.Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+	} else {
+		// cluster-scoped resources
+		if name != "" {
+			obj, err := ri.Get(ctx, name, metav1.GetOptions{})
 			if err != nil {
 				return textErrorResult(formatK8sErr(err)), nil, nil
 			}
-			return marshalUnstructured(list), nil, nil
+			cleanGetObject(obj, clean, cleanStatus)
+			return marshalUnstructured(obj), nil, nil
 		}
 
-		list, err := ri.Namespace(namespace).List(ctx, metav1.ListOptions{})
+		list, err = ri.List(ctx, metav1.ListOptions{})
 		if err != nil {
 			return textErrorResult(formatK8sErr(err)), nil, nil
 		}
-		return marshalUnstructured(list), nil, nil
 	}
 
-	// cluster-scoped resources
-	if name != "" {
-		obj, err := ri.Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return textErrorResult(formatK8sErr(err)), nil, nil
+	if owner != nil {
+		kept := list.Items[:0]
+		for i := range list.Items {
+			if owner.matches(&list.Items[i]) {
+				kept = append(kept, list.Items[i])
+			}
 		}
-		return marshalUnstructured(obj), nil, nil
+		list.Items = kept
 	}
-
-	list, err := ri.List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return textErrorResult(formatK8sErr(err)), nil, nil
+	if sortBy != "" {
+		sortUnstructured(list.Items, sortBy, sortPath)
+	}
+	if reverse {
+		for i, j := 0, len(list.Items)-1; i < j; i, j = i+1, j-1 {
+			list.Items[i], list.Items[j] = list.Items[j], list.Items[i]
+		}
+	}
+	// After sorting, so sort_by can still use status fields.
+	for i := range list.Items {
+		cleanGetObject(&list.Items[i], clean, cleanStatus)
 	}
 	return marshalUnstructured(list), nil, nil
 }
 
+// cleanGetObject is the lightweight cleanup of k8s_get's clean/clean_status:
+// managedFields is dropped (it often outweighs the rest of the object), and
+// status too when dropStatus is set. See exportClean for the full version.
+func cleanGetObject(obj *unstructured.Unstructured, clean, dropStatus bool) {
+	if clean || dropStatus {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	}
+	if dropStatus {
+		unstructured.RemoveNestedField(obj.Object, "status")
+	}
+}
+
+// ownerFilter matches ownerReferences by kind (case-insensitive) and name.
+// Owners are always in the item's namespace (or cluster-scoped), so no
+// namespace is part of the spec.
+type ownerFilter struct {
+	kind string
+	name string
+}
+
+func parseOwnerFilter(spec string) (*ownerFilter, error) {
+	kind, name, ok := strings.Cut(strings.TrimSpace(spec), "/")
+	kind, name = strings.TrimSpace(kind), strings.TrimSpace(name)
+	if !ok || kind == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid owner %q (expected Kind/name, e.g. ReplicaSet/my-rs)", spec)
+	}
+	return &ownerFilter{kind: kind, name: name}, nil
+}
+
+func (f *ownerFilter) matches(obj *unstructured.Unstructured) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if strings.EqualFold(ref.Kind, f.kind) && ref.Name == f.name {
+			return true
+		}
+	}
+	return false
+}
+
+// sortUnstructured orders items like `kubectl get --sort-by`:
+// - "name": namespace, then name
+// - "created": metadata.creationTimestamp, oldest first
+// - otherwise path (from parseFieldPath): numbers numerically, everything else
+// as text; items without the field sort last
+func sortUnstructured(items []unstructured.Unstructured, sortBy string, path []any) {
+	switch sortBy {
+	case "name":
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].GetNamespace() != items[j].GetNamespace() {
+				return items[i].GetNamespace() < items[j].GetNamespace()
+			}
+			return items[i].GetName() < items[j].GetName()
+		})
+		return
+	case "created":
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].GetCreationTimestamp().Time.Before(items[j].GetCreationTimestamp().Time)
+		})
+		return
+	}
+
+	keys := make([]any, len(items))
+	for i := range items {
+		v, err := lookupFieldPath(items[i].Object, path)
+		if err == nil {
+			keys[i] = v
+		}
+	}
+	idx := make([]int, len(items))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return sortKeyLess(keys[idx[a]], keys[idx[b]])
+	})
+	sorted := make([]unstructured.Unstructured, len(items))
+	for i, k := range idx {
+		sorted[i] = items[k]
+	}
+	copy(items, sorted)
+}
+
+func sortKeyLess(a, b any) bool {
+	if a == nil || b == nil {
+		return a != nil
+	}
+	af, aNum := toFloat(a)
+	bf, bNum := toFloat(b)
+	if aNum && bNum {
+		return af < bf
+	}
+	return fmtAny(a) < fmtAny(b)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch t := v.(type) {
+	case int64:
+		return float64(t), true
+	case int:
+		return float64(t), true
+	case float64:
+		return t, true
+	}
+	return 0, false
+}
+
+// apiGroupRow is one API group in k8s_apis output ("" is the core group).
+type apiGroupRow struct {
+	Group            string   `json:"group"`
+	PreferredVersion string   `json:"preferred_version"`
+	Versions         []string `json:"versions"`
+	Resources        int      `json:"resources"` // discovered in the preferred version
+}
+
+// apiGroupFailure is a group version whose resources could not be discovered,
+// usually an aggregated API whose backing APIService is unavailable.
+type apiGroupFailure struct {
+	GroupVersion string `json:"group_version"`
+	APIService   string `json:"apiservice"`
+	Error        string `json:"error"`
+}
+
 // K8sApis: list APIs similar in spirit to Python k8s_apis().
 // Python returns /api versions via ApisApi().get_api_versions().
-// In Go we return discovery groups + resources (more complete, and useful).
+// In Go we return one row per group with its preferred and served versions,
+// plus failed_groups: the group versions discovery could not read (always
+// present, empty when discovery is complete). A broken aggregated API fails
+// only its own group, so the rest of the list is still returned.
 func K8sApis(ctx context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
 	disc, err := getDiscovery()
 	if err != nil {
@@ -105,18 +298,62 @@ func K8sApis(ctx context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mc
 	}
 
 	groups, resources, err := disc.ServerGroupsAndResources()
-	// This can fail partially due to auth; still return what we can.
-	partial := ""
+	failed := []apiGroupFailure{}
 	if err != nil {
-		partial = err.Error()
+		var gdf *discovery.ErrGroupDiscoveryFailed
+		if !errors.As(err, &gdf) {
+			if len(groups) == 0 {
+				return textErrorResult(formatK8sErr(err)), nil, nil
+			}
+			failed = append(failed, apiGroupFailure{Error: err.Error()})
+		} else {
+			for gv, gerr := range gdf.Groups {
+				failed = append(failed, apiGroupFailure{
+					GroupVersion: gv.String(),
+					APIService:   gv.Version + "." + gv.Group,
+					Error:        gerr.Error(),
+				})
+			}
+			sort.Slice(failed, func(i, j int) bool { return failed[i].GroupVersion < failed[j].GroupVersion })
+		}
 	}
 
+	counts := map[string]int{}
+	for _, rl := range resources {
+		if rl == nil {
+			continue
+		}
+		for _, r := range rl.APIResources {
+			// Skip subresources such as pods/log.
+			if !strings.Contains(r.Name, "/") {
+				counts[rl.GroupVersion]++
+			}
+		}
+	}
+
+	rows := make([]apiGroupRow, 0, len(groups))
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+		row := apiGroupRow{Group: g.Name, PreferredVersion: g.PreferredVersion.Version}
+		for _, v := range g.Versions {
+			row.Versions = append(row.Versions, v.Version)
+		}
+		if row.PreferredVersion == "" && len(row.Versions) > 0 {
+			row.PreferredVersion = row.Versions[0]
+		}
+		row.Resources = counts[schema.GroupVersion{Group: g.Name, Version: row.PreferredVersion}.String()]
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Group < rows[j].Group })
+
 	out := map[string]any{
-		"groups":    groups,
-		"resources": resources,
+		"groups":        rows,
+		"failed_groups": failed,
 	}
-	if partial != "" {
-		out["warning"] = "partial discovery failure: " + partial
+	if len(failed) > 0 {
+		out["warning"] = fmt.Sprintf("partial discovery failure: %d group version(s) could not be discovered; check the listed APIServices (kubectl get apiservices)", len(failed))
 	}
 
 	b, _ := json.MarshalIndent(out, "", "  ")
@@ -139,6 +376,91 @@ func K8sCrds(ctx context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mc
 	return textOKResult(string(b)), nil, nil
 }
 
+// K8sListCR lists instances of a custom resource identified by group and kind,
+// resolving the plural and version from its CRD.
+//
+// Args:
+// - group, kind required (kind also matches the CRD's plural, singular or short names)
+// - namespace="" means all namespaces (ignored for cluster-scoped CRDs)
+func K8sListCR(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	group, _ := args["group"].(string)
+	kind, _ := args["kind"].(string)
+	namespace, _ := args["namespace"].(string)
+
+	if strings.TrimSpace(group) == "" {
+		return textErrorResult("group is required"), nil, nil
+	}
+	if strings.TrimSpace(kind) == "" {
+		return textErrorResult("kind is required"), nil, nil
+	}
+
+	ext, err := getAPIExtensions()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	crds, err := ext.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	var crd *apiextensionsv1.CustomResourceDefinition
+	for i := range crds.Items {
+		c := &crds.Items[i]
+		if c.Spec.Group == group && crdNameMatches(c.Spec.Names, kind) {
+			crd = c
+			break
+		}
+	}
+	if crd == nil {
+		return textErrorResult(fmt.Sprintf("Error: no CRD found for kind '%s' in group '%s'", kind, group)), nil, nil
+	}
+
+	version := crdServedVersion(crd)
+	if version == "" {
+		return textErrorResult(fmt.Sprintf("Error: CRD '%s' has no served version", crd.Name)), nil, nil
+	}
+	gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: crd.Spec.Names.Plural}
+
+	var list *unstructured.UnstructuredList
+	if crd.Spec.Scope == apiextensionsv1.NamespaceScoped && namespace != "" {
+		list, err = dyn.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	} else {
+		list, err = dyn.Resource(gvr).List(ctx, metav1.ListOptions{})
+	}
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	return marshalUnstructured(list), nil, nil
+}
+
+func crdNameMatches(names apiextensionsv1.CustomResourceDefinitionNames, target string) bool {
+	if strings.EqualFold(names.Kind, target) || target == names.Plural || target == names.Singular {
+		return true
+	}
+	return stringInSlice(target, names.ShortNames)
+}
+
+// crdServedVersion prefers the storage version when it is served, then the first
+// served version in spec order.
+func crdServedVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage && v.Served {
+			return v.Name
+		}
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Served {
+			return v.Name
+		}
+	}
+	return ""
+}
+
 // ---- helpers ----
 
 func marshalUnstructured(obj interface{}) *mcp.CallToolResult {
@@ -156,6 +478,9 @@ func formatK8sErr(err error) string {
 	if apierrors.IsUnauthorized(err) {
 		return "Error:\nUnauthorized: " + err.Error()
 	}
+	if apierrors.IsConflict(err) {
+		return "Error:\nConflict: " + err.Error() + "\nThe object changed since resource_version was read; get it again and retry."
+	}
 	return "Error:\n" + err.Error()
 }
 
@@ -205,6 +530,46 @@ func findGVR(disc discovery.DiscoveryInterface, target string) (schema.GroupVers
 	return schema.GroupVersionResource{}, false, false
 }
 
+// withVersion pins gvr to version (as found by findGVR, which picks the
+// preferred one). The version must be served for the same resource; otherwise
+// the error lists the versions that are.
+func withVersion(disc discovery.DiscoveryInterface, gvr schema.GroupVersionResource, version string) (schema.GroupVersionResource, error) {
+	version = strings.TrimSpace(version)
+	if version == "" || version == gvr.Version {
+		return gvr, nil
+	}
+	gvr.Version = version
+	if rl, err := disc.ServerResourcesForGroupVersion(gvr.GroupVersion().String()); err == nil {
+		for _, r := range rl.APIResources {
+			if r.Name == gvr.Resource {
+				return gvr, nil
+			}
+		}
+	}
+
+	var served []string
+	if groups, err := disc.ServerGroups(); err == nil {
+		for _, g := range groups.Groups {
+			if g.Name != gvr.Group {
+				continue
+			}
+			for _, v := range g.Versions {
+				rl, err := disc.ServerResourcesForGroupVersion(v.GroupVersion)
+				if err != nil {
+					continue
+				}
+				for _, r := range rl.APIResources {
+					if r.Name == gvr.Resource {
+						served = append(served, v.Version)
+						break
+					}
+				}
+			}
+		}
+	}
+	return gvr, fmt.Errorf("Error: version '%s' is not served for %s (served: %s)", version, schema.GroupResource{Group: gvr.Group, Resource: gvr.Resource}.String(), strings.Join(served, ", "))
+}
+
 func matchResource(res metav1.APIResource, target string) bool {
 	if target == res.Name {
 		return true
@@ -0,0 +1,140 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// getAllConcurrency bounds the number of parallel List calls in k8s_get_all.
+const getAllConcurrency = 8
+
+type getAllKind struct {
+	Resource string   `json:"resource"`
+	Kind     string   `json:"kind"`
+	Count    int      `json:"count"`
+	Items    []string `json:"items"`
+}
+
+type getAllError struct {
+	Resource string `json:"resource"`
+	Error    string `json:"error"`
+}
+
+// K8sGetAll is a broader `kubectl get all`: every namespaced resource that supports
+// list (from discovery) is listed concurrently; per-kind failures are reported, not fatal.
+//
+// Args:
+// - namespace (string) defaults to defaultNamespace()
+// - include_events (bool) default false; events are noisy and rarely what "all" means
+func K8sGetAll(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	namespace, _ := args["namespace"].(string)
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	includeEvents := boolFromArgs(args, "include_events", false)
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	// Partial discovery failures still return usable lists.
+	lists, discErr := disc.ServerPreferredNamespacedResources()
+
+	type target struct {
+		gvr  schema.GroupVersionResource
+		kind string
+	}
+	var targets []target
+	for _, rl := range lists {
+		gv, err := schema.ParseGroupVersion(rl.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range rl.APIResources {
+			if strings.Contains(r.Name, "/") || !stringInSlice("list", r.Verbs) {
+				continue
+			}
+			if !includeEvents && r.Name == "events" {
+				continue
+			}
+			targets = append(targets, target{
+				gvr:  gv.WithResource(r.Name),
+				kind: r.Kind,
+			})
+		}
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		kinds  []getAllKind
+		errs   []getAllError
+		tokens = make(chan struct{}, getAllConcurrency)
+	)
+
+	for _, t := range targets {
+		wg.Add(1)
+		go func(t target) {
+			defer wg.Done()
+			tokens <- struct{}{}
+			defer func() { <-tokens }()
+
+			resName := t.gvr.Resource
+			if t.gvr.Group != "" {
+				resName += "." + t.gvr.Group
+			}
+
+			list, err := dyn.Resource(t.gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, getAllError{Resource: resName, Error: err.Error()})
+				return
+			}
+			if len(list.Items) == 0 {
+				return
+			}
+			names := make([]string, 0, len(list.Items))
+			for i := range list.Items {
+				names = append(names, list.Items[i].GetName())
+			}
+			sort.Strings(names)
+			kinds = append(kinds, getAllKind{
+				Resource: resName,
+				Kind:     t.kind,
+				Count:    len(names),
+				Items:    names,
+			})
+		}(t)
+	}
+	wg.Wait()
+
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].Resource < kinds[j].Resource })
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Resource < errs[j].Resource })
+
+	out := map[string]any{
+		"namespace": namespace,
+		"resources": kinds,
+	}
+	if len(errs) > 0 {
+		out["errors"] = errs
+	}
+	if discErr != nil {
+		out["warning"] = "partial discovery failure: " + discErr.Error()
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// checkProtected is the guard for mutations of cluster-critical kinds
+// (--protected-kinds, e.g. Namespace or CustomResourceDefinition): unless args
+// carry confirm=true it returns an error naming the object and the action.
+// Every tool that changes objects through the dynamic client calls it, as do
+// k8s_suspend/k8s_resume. The remaining typed-client tools (rollout
+// restart/undo/pause/resume, cordon/drain, debug, the configmap/secret/job
+// creators) are exempt.
+func checkProtected(gvr schema.GroupVersionResource, name string, args map[string]any, action string) error {
+	return checkProtectedConfirmed(gvr, name, boolFromArgs(args, "confirm", false), action)
+}
+
+// checkProtectedConfirmed is checkProtected for callers that already read
+// confirm, such as the per-document check of apply/create.
+func checkProtectedConfirmed(gvr schema.GroupVersionResource, name string, confirmed bool, action string) error {
+	kind := kindForGVR(gvr)
+	if !isProtectedKind(kind) || confirmed {
+		return nil
+	}
+	return fmt.Errorf("Error: %s is a protected kind; %s %s/%s requires confirm=true", kind, action, kind, name)
+}
+
+func isProtectedKind(kind string) bool {
+	return protectedKinds[strings.ToLower(kind)]
+}
+
+// kindForGVR resolves gvr's kind through the REST mapper, falling back to
+// singularizing the resource name.
+func kindForGVR(gvr schema.GroupVersionResource) string {
+	if mapper, err := GetRESTMapper(); err == nil {
+		if gvk, err := mapper.KindFor(gvr); err == nil {
+			return gvk.Kind
+		}
+	}
+	return kindFromResourceType(gvr.Resource)
+}
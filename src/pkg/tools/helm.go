@@ -0,0 +1,171 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// HelmTemplateArgs is the typed input for k8s_helm_template.
+type HelmTemplateArgs struct {
+	Chart      string         `json:"chart" jsonschema:"Path to a chart directory or packaged chart (.tgz) on the server"`
+	Values     map[string]any `json:"values,omitempty" jsonschema:"Values to override the chart defaults"`
+	ValuesYAML string         `json:"values_yaml,omitempty" jsonschema:"Values as a YAML document (merged under values)"`
+	Namespace  string         `json:"namespace,omitempty" jsonschema:"Release namespace (default: the server default namespace)"`
+	Release    string         `json:"release,omitempty" jsonschema:"Release name (default: release-name)"`
+	Apply      bool           `json:"apply,omitempty" jsonschema:"Server-side apply the rendered manifests in helm install order (hooks are not applied)"`
+	Confirm    bool           `json:"confirm,omitempty" jsonschema:"Allow apply to change objects of a protected kind (--protected-kinds)"`
+}
+
+type helmManifest struct {
+	Source  string `json:"source"`
+	Content string `json:"content"`
+}
+
+// helmHook is a manifest annotated with helm.sh/hook (tests, pre-install Jobs,
+// ...). Hooks are shown but never applied: they only make sense when helm runs
+// them at the matching release event.
+type helmHook struct {
+	Source  string   `json:"source"`
+	Events  []string `json:"events"`
+	Content string   `json:"content"`
+}
+
+// RegisterHelmTemplateTool renders charts in-process with the helm SDK, so no helm
+// binary is needed. Rendering is read-only; apply=true is refused when writes are disabled.
+func RegisterHelmTemplateTool(srv *mcp.Server, disableWrite bool) {
+	mcp.AddTool(srv, &mcp.Tool{
+		Name:        "k8s_helm_template",
+		Description: "Render a Helm chart to manifests without the helm binary (optionally apply them)",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args HelmTemplateArgs) (*mcp.CallToolResult, any, error) {
+		ctx = withSession(ctx, req)
+		if strings.TrimSpace(args.Chart) == "" {
+			return textErrorResult("chart is required"), nil, nil
+		}
+		if args.Apply && disableWrite {
+			return textErrorResult("Error: Write operations are not allowed. Cannot apply rendered chart."), nil, nil
+		}
+
+		namespace := args.Namespace
+		if namespace == "" {
+			namespace = defaultNamespace(ctx)
+		}
+		release := args.Release
+		if release == "" {
+			release = "release-name"
+		}
+
+		manifests, hooks, err := renderHelmChart(args.Chart, args.Values, args.ValuesYAML, release, namespace)
+		if err != nil {
+			return textErrorResult("Error:\n" + err.Error()), nil, nil
+		}
+
+		out := map[string]any{
+			"release":   release,
+			"namespace": namespace,
+			"manifests": manifests,
+		}
+		if len(hooks) > 0 {
+			out["hooks"] = hooks
+		}
+
+		if args.Apply {
+			docs := make([]string, 0, len(manifests))
+			for _, m := range manifests {
+				docs = append(docs, m.Content)
+			}
+			applied, err := k8sCreateOrApply(ctx, strings.Join(docs, "\n---\n"), namespace, true, false, args.Confirm)
+			if err != nil {
+				return textErrorResult(err.Error()), nil, nil
+			}
+			out["apply"] = json.RawMessage(applied)
+		}
+
+		b, _ := json.MarshalIndent(out, "", "  ")
+		return textOKResult(string(b)), nil, nil
+	})
+}
+
+// renderHelmChart mirrors `helm template`: load chart, coalesce values, render templates.
+// Partials, NOTES.txt and empty documents are dropped. Manifests come back one per
+// document in helm's install order (CRDs and Namespaces before what lives in them),
+// with helm.sh/hook documents split out as hooks.
+func renderHelmChart(chartPath string, values map[string]any, valuesYAML string, release, namespace string) ([]helmManifest, []helmHook, error) {
+	ch, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load chart: %w", err)
+	}
+
+	vals := chartutil.Values{}
+	if strings.TrimSpace(valuesYAML) != "" {
+		parsed, err := chartutil.ReadValues([]byte(valuesYAML))
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse values_yaml: %w", err)
+		}
+		vals = parsed
+	}
+	if len(values) > 0 {
+		// values wins over values_yaml on conflicting keys, nested tables are merged.
+		vals = chartutil.MergeTables(values, vals)
+	}
+
+	if err := chartutil.ProcessDependenciesWithMerge(ch, vals); err != nil {
+		return nil, nil, fmt.Errorf("process dependencies: %w", err)
+	}
+
+	caps := chartutil.DefaultCapabilities.Copy()
+	if disc, err := getDiscovery(); err == nil {
+		if v, err := disc.ServerVersion(); err == nil {
+			caps.KubeVersion = chartutil.KubeVersion{Version: v.GitVersion, Major: v.Major, Minor: v.Minor}
+		}
+	}
+
+	renderVals, err := chartutil.ToRenderValues(ch, vals, chartutil.ReleaseOptions{
+		Name:      release,
+		Namespace: namespace,
+		Revision:  1,
+		IsInstall: true,
+	}, caps)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build render values: %w", err)
+	}
+
+	rendered, err := engine.Render(ch, renderVals)
+	if err != nil {
+		return nil, nil, fmt.Errorf("render chart: %w", err)
+	}
+
+	files := make(map[string]string, len(rendered))
+	for src, content := range rendered {
+		if strings.EqualFold(path.Base(src), "NOTES.txt") {
+			continue
+		}
+		files[src] = content
+	}
+	rawHooks, sorted, err := releaseutil.SortManifests(files, caps.APIVersions, releaseutil.InstallOrder)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sort manifests: %w", err)
+	}
+
+	manifests := make([]helmManifest, 0, len(sorted))
+	for _, m := range sorted {
+		manifests = append(manifests, helmManifest{Source: m.Name, Content: m.Content})
+	}
+	hooks := make([]helmHook, 0, len(rawHooks))
+	for _, h := range rawHooks {
+		events := make([]string, 0, len(h.Events))
+		for _, e := range h.Events {
+			events = append(events, e.String())
+		}
+		hooks = append(hooks, helmHook{Source: h.Path, Events: events, Content: h.Manifest})
+	}
+	return manifests, hooks, nil
+}
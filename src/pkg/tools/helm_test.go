@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestChart(t *testing.T, templates map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{"Chart.yaml": "apiVersion: v2\nname: demo\nversion: 0.1.0\n"}
+	for name, content := range templates {
+		files[filepath.Join("templates", name)] = content
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRenderHelmChartInstallOrderAndHooks(t *testing.T) {
+	chart := writeTestChart(t, map[string]string{
+		"a-widget.yaml": "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n",
+		"b-deploy.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n",
+		"c-crd.yaml":    "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: widgets.example.com\n",
+		"d-ns.yaml":     "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: demo\n",
+		"e-test.yaml":   "apiVersion: v1\nkind: Pod\nmetadata:\n  name: demo-test\n  annotations:\n    helm.sh/hook: test\n",
+		"NOTES.txt":     "thanks\n",
+		"_helpers.tpl":  "{{- define \"demo.name\" -}}demo{{- end -}}\n",
+	})
+
+	manifests, hooks, err := renderHelmChart(chart, nil, "", "rel", "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sources []string
+	for _, m := range manifests {
+		sources = append(sources, m.Source)
+	}
+	want := "demo/templates/d-ns.yaml demo/templates/c-crd.yaml demo/templates/b-deploy.yaml demo/templates/a-widget.yaml"
+	if got := strings.Join(sources, " "); got != want {
+		t.Errorf("manifest order:\n got %s\nwant %s", got, want)
+	}
+	if len(hooks) != 1 || hooks[0].Source != "demo/templates/e-test.yaml" || strings.Join(hooks[0].Events, ",") != "test" {
+		t.Errorf("hooks = %+v, want the test pod only", hooks)
+	}
+}
@@ -0,0 +1,187 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// imageRef is a container image reference split into its parts, with the
+// registry defaults the container runtime applies ("nginx" is
+// docker.io/library/nginx:latest).
+type imageRef struct {
+	Repo   string // registry/path, normalized
+	Tag    string
+	Digest string // "sha256:..."
+}
+
+func (r imageRef) String() string {
+	s := r.Repo
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// parseImageRef splits image into repo, tag and digest. A missing tag is
+// "latest" unless the image is pinned by digest.
+func parseImageRef(image string) imageRef {
+	var ref imageRef
+	name := strings.TrimSpace(image)
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+	}
+	// A ':' after the last '/' is the tag; before it, a registry port.
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	first, rest, hasSlash := strings.Cut(name, "/")
+	switch {
+	case !hasSlash:
+		name = "docker.io/library/" + name
+	case !strings.ContainsAny(first, ".:") && first != "localhost":
+		name = "docker.io/" + name
+	case first == "index.docker.io":
+		name = "docker.io/" + rest
+	}
+	if strings.HasPrefix(name, "docker.io/") && strings.Count(name, "/") == 1 {
+		name = "docker.io/library/" + strings.TrimPrefix(name, "docker.io/")
+	}
+	ref.Repo = strings.ToLower(name)
+	return ref
+}
+
+type imageUseRow struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Init      bool   `json:"init,omitempty"`
+	Image     string `json:"image"`
+	ImageID   string `json:"image_id,omitempty"`
+	Node      string `json:"node,omitempty"`
+	Phase     string `json:"phase,omitempty"`
+}
+
+// K8sPodsUsingImage finds the pods whose containers (init containers
+// included) run an image, e.g. to scope a vulnerable image during an incident.
+// Image names are normalized before matching, so "nginx" and
+// "docker.io/library/nginx:latest" are the same image. A digest argument also
+// matches the digest the runtime resolved (status imageID), so pods that
+// pulled a tag pointing at it are found too.
+//
+// Args:
+// - image (required)
+// - match: "exact" (default; repo, tag and digest), "repo" (any tag/digest of the
+// repository) or "prefix" (normalized reference starts with image, e.g. a registry or org)
+// - namespace defaults to defaultNamespace(); all_namespaces (bool)
+func K8sPodsUsingImage(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	image := strings.TrimSpace(getStringArg(args, "image"))
+	match := strings.ToLower(getStringArg(args, "match"))
+	namespace := getStringArg(args, "namespace")
+	allNamespaces := boolFromArgs(args, "all_namespaces", false)
+
+	if image == "" {
+		return textErrorResult("image is required"), nil, nil
+	}
+	if match == "" {
+		match = "exact"
+	}
+	if match != "exact" && match != "repo" && match != "prefix" {
+		return textErrorResult(fmt.Sprintf("Error: unsupported match '%s' (expected exact, repo or prefix)", match)), nil, nil
+	}
+	if allNamespaces {
+		namespace = metav1.NamespaceAll
+	} else if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	want := parseImageRef(image)
+	// For prefix matching only the registry defaults apply, not ":latest".
+	prefix := strings.ToLower(image)
+	if !strings.Contains(image, ":") && !strings.Contains(image, "@") {
+		prefix = want.Repo
+	}
+	matches := func(image, imageID string) bool {
+		got := parseImageRef(image)
+		switch match {
+		case "repo":
+			return got.Repo == want.Repo
+		case "prefix":
+			return strings.HasPrefix(got.String(), prefix) || strings.HasPrefix(strings.ToLower(image), prefix)
+		}
+		if want.Digest != "" {
+			return got.Repo == want.Repo && (got.Digest == want.Digest || strings.HasSuffix(imageID, "@"+want.Digest))
+		}
+		return got.Repo == want.Repo && got.Tag == want.Tag
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	pods, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	rows := []imageUseRow{}
+	podsMatched := map[string]bool{}
+	for _, p := range pods.Items {
+		imageIDs := map[string]string{}
+		for _, st := range append(append([]v1.ContainerStatus{}, p.Status.InitContainerStatuses...), p.Status.ContainerStatuses...) {
+			imageIDs[st.Name] = st.ImageID
+		}
+		add := func(name, img string, init bool) {
+			if !matches(img, imageIDs[name]) {
+				return
+			}
+			podsMatched[p.Namespace+"/"+p.Name] = true
+			rows = append(rows, imageUseRow{
+				Namespace: p.Namespace,
+				Pod:       p.Name,
+				Container: name,
+				Init:      init,
+				Image:     img,
+				ImageID:   imageIDs[name],
+				Node:      p.Spec.NodeName,
+				Phase:     string(p.Status.Phase),
+			})
+		}
+		for _, c := range p.Spec.InitContainers {
+			add(c.Name, c.Image, true)
+		}
+		for _, c := range p.Spec.Containers {
+			add(c.Name, c.Image, false)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Namespace != rows[j].Namespace {
+			return rows[i].Namespace < rows[j].Namespace
+		}
+		return rows[i].Pod < rows[j].Pod
+	})
+
+	b, _ := json.MarshalIndent(map[string]any{
+		"image":      image,
+		"normalized": want.String(),
+		"match":      match,
+		"pods":       len(podsMatched),
+		"containers": rows,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
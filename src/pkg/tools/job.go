@@ -0,0 +1,366 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+type jobContainerResult struct {
+	Name       string           `json:"name"`
+	State      string           `json:"state"`
+	Terminated *terminationInfo `json:"terminated,omitempty"`
+	Logs       string           `json:"logs,omitempty"`
+	LogError   string           `json:"log_error,omitempty"`
+}
+
+type jobPodResult struct {
+	Name       string               `json:"name"`
+	Phase      string               `json:"phase"`
+	Node       string               `json:"node,omitempty"`
+	Failed     bool                 `json:"failed"`
+	Containers []jobContainerResult `json:"containers"`
+}
+
+// K8sJobResult waits for a Job to finish and reports how it went: completion
+// status, counts, and each pod's container exit info and logs. Failed pods are
+// listed first.
+//
+// Args:
+// - name (required); namespace defaults to defaultNamespace()
+// - kind: "job" (default) or "cronjob" to target the CronJob's most recent Job
+// - wait (bool, default true); timeout seconds, default 300, max 1800
+// - tail: log lines per container, default 200
+func K8sJobResult(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	name := getStringArg(args, "name")
+	namespace := getStringArg(args, "namespace")
+	kind := strings.ToLower(getStringArg(args, "kind"))
+	wait := boolFromArgs(args, "wait", true)
+	timeout := intFromArgsDefault(args, "timeout", 300)
+	tail := int64(intFromArgsDefault(args, "tail", 200))
+
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	if timeout <= 0 {
+		timeout = 300
+	}
+	if timeout > 1800 {
+		timeout = 1800
+	}
+	if tail <= 0 || tail > maxLogTailLines {
+		tail = maxLogTailLines
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	jobName := name
+	switch kind {
+	case "", "job", "jobs":
+	case "cronjob", "cronjobs", "cj":
+		jobName, err = latestCronJobJob(ctx, cs, namespace, name)
+		if err != nil {
+			return textErrorResult(err.Error()), nil, nil
+		}
+	default:
+		return textErrorResult(fmt.Sprintf("Error: unsupported kind '%s' (expected job or cronjob)", kind)), nil, nil
+	}
+
+	job, err := cs.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	timedOut := false
+	if wait && jobFinishState(job) == "" {
+		wctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		job, err = waitJobFinished(wctx, cs, namespace, jobName)
+		cancel()
+		if err != nil {
+			if job == nil {
+				return textErrorResult(formatK8sErr(err)), nil, nil
+			}
+			timedOut = true
+		}
+	}
+
+	status := jobFinishState(job)
+	if status == "" {
+		status = "running"
+	}
+	out := map[string]any{
+		"job":       job.Name,
+		"namespace": namespace,
+		"status":    status,
+		"active":    job.Status.Active,
+		"succeeded": job.Status.Succeeded,
+		"failed":    job.Status.Failed,
+	}
+	if kind == "cronjob" || kind == "cronjobs" || kind == "cj" {
+		out["cronjob"] = name
+	}
+	if timedOut {
+		out["timed_out"] = true
+	}
+	if job.Status.StartTime != nil {
+		out["start_time"] = formatMetaTime(*job.Status.StartTime)
+		end := time.Now()
+		if job.Status.CompletionTime != nil {
+			out["completion_time"] = formatMetaTime(*job.Status.CompletionTime)
+			end = job.Status.CompletionTime.Time
+		}
+		out["duration"] = end.Sub(job.Status.StartTime.Time).Round(time.Second).String()
+	}
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == v1.ConditionTrue {
+			out["failure_reason"] = c.Reason
+			out["failure_message"] = c.Message
+		}
+	}
+
+	pods, err := jobPods(ctx, cs, job)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	results := make([]jobPodResult, 0, len(pods))
+	for i := range pods {
+		results = append(results, jobPodSummary(ctx, cs, &pods[i], tail))
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Failed != results[j].Failed {
+			return results[i].Failed
+		}
+		return results[i].Name < results[j].Name
+	})
+	out["pods"] = results
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// latestCronJobJob returns the newest Job controlled by the named CronJob.
+func latestCronJobJob(ctx context.Context, cs *kubernetes.Clientset, namespace, cronJob string) (string, error) {
+	cj, err := cs.BatchV1().CronJobs(namespace).Get(ctx, cronJob, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("%s", formatK8sErr(err))
+	}
+	jobs, err := cs.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("%s", formatK8sErr(err))
+	}
+	var latest *batchv1.Job
+	for i := range jobs.Items {
+		j := &jobs.Items[i]
+		ref := metav1.GetControllerOf(j)
+		if ref == nil || ref.UID != cj.UID {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&j.CreationTimestamp) {
+			latest = j
+		}
+	}
+	if latest == nil {
+		return "", fmt.Errorf("Error: cronjob '%s' has no jobs yet", cronJob)
+	}
+	return latest.Name, nil
+}
+
+// jobFinishState is "succeeded" or "failed" once the Job has the matching
+// condition, else "".
+func jobFinishState(job *batchv1.Job) string {
+	for _, c := range job.Status.Conditions {
+		if c.Status != v1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return "succeeded"
+		case batchv1.JobFailed:
+			return "failed"
+		}
+	}
+	return ""
+}
+
+// waitJobFinished polls until the Job completes or fails. On timeout it returns
+// the last Job it saw together with the error.
+func waitJobFinished(ctx context.Context, cs *kubernetes.Clientset, namespace, name string) (*batchv1.Job, error) {
+	t := time.NewTicker(2 * time.Second)
+	defer t.Stop()
+
+	var last *batchv1.Job
+	for {
+		job, err := cs.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			last = job
+			if jobFinishState(job) != "" {
+				return job, nil
+			}
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			if last == nil && err != nil {
+				return nil, err
+			}
+			return last, fmt.Errorf("job not finished: %v", ctx.Err())
+		}
+	}
+}
+
+func jobPods(ctx context.Context, cs *kubernetes.Clientset, job *batchv1.Job) ([]v1.Pod, error) {
+	if job.Spec.Selector == nil {
+		return nil, nil
+	}
+	sel, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := cs.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: sel.String()})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+func jobPodSummary(ctx context.Context, cs *kubernetes.Clientset, pod *v1.Pod, tail int64) jobPodResult {
+	r := jobPodResult{
+		Name:   pod.Name,
+		Phase:  string(pod.Status.Phase),
+		Node:   pod.Spec.NodeName,
+		Failed: pod.Status.Phase == v1.PodFailed,
+	}
+
+	statuses := map[string]v1.ContainerStatus{}
+	for _, st := range pod.Status.ContainerStatuses {
+		statuses[st.Name] = st
+	}
+	for _, c := range pod.Spec.Containers {
+		cr := jobContainerResult{Name: c.Name, State: "waiting"}
+		if st, ok := statuses[c.Name]; ok {
+			switch {
+			case st.State.Terminated != nil:
+				t := st.State.Terminated
+				cr.State = "terminated"
+				cr.Terminated = &terminationInfo{
+					ExitCode:   t.ExitCode,
+					Signal:     t.Signal,
+					Reason:     t.Reason,
+					Message:    t.Message,
+					StartedAt:  formatMetaTime(t.StartedAt),
+					FinishedAt: formatMetaTime(t.FinishedAt),
+				}
+				if t.ExitCode != 0 {
+					r.Failed = true
+				}
+			case st.State.Running != nil:
+				cr.State = "running"
+			case st.State.Waiting != nil && st.State.Waiting.Reason != "":
+				cr.State = "waiting: " + st.State.Waiting.Reason
+			}
+		}
+
+		if cr.State == "running" || cr.State == "terminated" {
+			logs, err := cs.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+				Container: c.Name,
+				TailLines: &tail,
+			}).DoRaw(ctx)
+			if err != nil {
+				cr.LogError = err.Error()
+			} else {
+				cr.Logs = string(logs)
+			}
+		}
+		r.Containers = append(r.Containers, cr)
+	}
+	return r
+}
+
+// K8sTriggerCronJob ports `kubectl create job --from=cronjob/<name>`: it creates
+// a Job from the CronJob's jobTemplate, owned by the CronJob and annotated
+// cronjob.kubernetes.io/instantiate=manual. Suspended CronJobs can be
+// triggered too, as with kubectl.
+//
+// Args: name (required); namespace defaults to defaultNamespace();
+// job_name defaults to "<name>-manual-<random>"
+func K8sTriggerCronJob(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	name := getStringArg(args, "name")
+	namespace := getStringArg(args, "namespace")
+	jobName := getStringArg(args, "job_name")
+
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	cj, err := cs.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	if jobName == "" {
+		// Job names end up in pod labels, so keep them within 63 characters.
+		base := name
+		if len(base) > 50 {
+			base = strings.TrimRight(base[:50], "-.")
+		}
+		jobName = base + "-manual-" + utilrand.String(5)
+	}
+
+	annotations := map[string]string{"cronjob.kubernetes.io/instantiate": "manual"}
+	for k, v := range cj.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+	labels := map[string]string{}
+	for k, v := range cj.Spec.JobTemplate.Labels {
+		labels[k] = v
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        jobName,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cj, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: *cj.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+
+	created, err := cs.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	b, _ := json.MarshalIndent(map[string]any{
+		"cronjob":   name,
+		"namespace": namespace,
+		"job":       created.Name,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// rawKubeconfig loads the merged kubeconfig (KUBECONFIG or ~/.kube/config)
+// the way SetupClient does.
+func rawKubeconfig() (clientcmdapi.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if envKube := os.Getenv("KUBECONFIG"); envKube != "" {
+		loadingRules.ExplicitPath = envKube
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).RawConfig()
+}
+
+// K8sCurrentContext reports which cluster and identity the server talks to and
+// the namespace tools use when none is given, with where that namespace comes
+// from (k8s_set_namespace, --namespace, the kubeconfig context or the
+// built-in "default"). Running in a pod, the in-cluster service account is
+// used instead of a kubeconfig context.
+func K8sCurrentContext(ctx context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+	namespace, source := defaultNamespaceSource(ctx)
+	out := map[string]any{
+		"namespace":        namespace,
+		"namespace_source": source,
+	}
+
+	if cfg, err := rest.InClusterConfig(); err == nil {
+		out["in_cluster"] = true
+		out["server"] = cfg.Host
+		out["user"] = "in-cluster service account"
+	} else {
+		raw, err := rawKubeconfig()
+		if err != nil {
+			return textErrorResult("Error:\n" + err.Error()), nil, nil
+		}
+		if raw.CurrentContext == "" {
+			return textErrorResult("Error:\nno current context set in kubeconfig"), nil, nil
+		}
+		ctxObj, ok := raw.Contexts[raw.CurrentContext]
+		if !ok || ctxObj == nil {
+			return textErrorResult(fmt.Sprintf("Error:\ncurrent context '%s' not found in kubeconfig", raw.CurrentContext)), nil, nil
+		}
+		out["context"] = raw.CurrentContext
+		out["cluster"] = ctxObj.Cluster
+		out["user"] = ctxObj.AuthInfo
+		if c, ok := raw.Clusters[ctxObj.Cluster]; ok && c != nil {
+			out["server"] = c.Server
+		}
+		if ctxObj.Namespace != "" {
+			out["context_namespace"] = ctxObj.Namespace
+		}
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// K8sSetNamespace changes the namespace later tool calls use when they are
+// not given one, like `kubectl config set-context --current --namespace` but
+// kept in memory only: the kubeconfig is not touched and the choice ends with
+// the MCP session. Other clients of an HTTP server keep their own default.
+//
+// Args:
+// - namespace (required unless reset)
+// - reset (bool): drop the override and go back to the startup default
+// The namespace must exist unless the caller may not read namespaces.
+func K8sSetNamespace(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	namespace := strings.TrimSpace(getStringArg(args, "namespace"))
+	reset := boolFromArgs(args, "reset", false)
+
+	if reset {
+		setSessionNamespace(ctx, "")
+	} else {
+		if namespace == "" {
+			return textErrorResult("namespace is required (or reset=true)"), nil, nil
+		}
+		cs, err := getClient()
+		if err != nil {
+			return textErrorResult(err.Error()), nil, nil
+		}
+		// Forbidden only means the caller can't read the Namespace object;
+		// it may still work inside it.
+		if _, err := cs.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{}); err != nil && !apierrors.IsForbidden(err) {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		setSessionNamespace(ctx, namespace)
+	}
+
+	ns, source := defaultNamespaceSource(ctx)
+	b, _ := json.MarshalIndent(map[string]any{
+		"namespace":        ns,
+		"namespace_source": source,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
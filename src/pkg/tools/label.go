@@ -0,0 +1,318 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// metadataPlan is the per-object outcome of a label/annotate call. Before/After
+// only hold the keys the call touches; a missing key means "not set".
+type metadataPlan struct {
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name"`
+	Before    map[string]string `json:"before"`
+	After     map[string]string `json:"after"`
+	Changed   bool              `json:"changed"`
+	Status    string            `json:"status"`
+	Message   string            `json:"message,omitempty"`
+}
+
+// K8sLabel ports `kubectl label`, plus a selector-driven bulk mode.
+//
+// Args:
+// - resource_type required; name or selector (label selector) required
+// - namespace defaults to defaultNamespace(); all_namespaces (bool) with selector
+// - labels: object {"k": "v", "old": null} or string "k=v,old-" (trailing '-' removes)
+// - in the string form values may contain spaces and commas (see splitMetadataEntries)
+// - overwrite (bool) default false, like kubectl
+// - dry_run (bool): server-side dry run (DryRun=All), returns the plan without writing
+// - resource_version: optional precondition (name mode only)
+func K8sLabel(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	return k8sSetMetadata(ctx, args, "labels")
+}
+
+// K8sAnnotate ports `kubectl annotate`; arguments match K8sLabel with "annotations"
+// in place of "labels".
+func K8sAnnotate(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	return k8sSetMetadata(ctx, args, "annotations")
+}
+
+func k8sSetMetadata(ctx context.Context, args map[string]any, field string) (*mcp.CallToolResult, any, error) {
+	resourceType, _ := args["resource_type"].(string)
+	name := getStringArg(args, "name", "resource_name")
+	selector, _ := args["selector"].(string)
+	namespace, _ := args["namespace"].(string)
+	allNamespaces := boolFromArgs(args, "all_namespaces", false)
+	overwrite := boolFromArgs(args, "overwrite", false)
+	dryRun := boolFromArgs(args, "dry_run", false)
+	resourceVersion := getStringArg(args, "resource_version")
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" && strings.TrimSpace(selector) == "" {
+		return textErrorResult("name or selector is required"), nil, nil
+	}
+	if name != "" && selector != "" {
+		return textErrorResult("Error: name and selector are mutually exclusive"), nil, nil
+	}
+	if selector != "" && resourceVersion != "" {
+		return textErrorResult("Error: resource_version can only be used with name"), nil, nil
+	}
+	if selector != "" {
+		if _, err := labels.Parse(selector); err != nil {
+			return textErrorResult(fmt.Sprintf("Error: invalid selector %q: %v", selector, err)), nil, nil
+		}
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	set, remove, err := parseMetadataChanges(args[field])
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+	if len(set) == 0 && len(remove) == 0 {
+		return textErrorResult(field + " is required"), nil, nil
+	}
+	if field == "labels" {
+		if _, err := labels.ValidatedSelectorFromSet(set); err != nil {
+			return textErrorResult("Error: invalid labels: " + err.Error()), nil, nil
+		}
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		gvr, namespaced, found = findGVR(disc, resourceType+"s")
+	}
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
+	}
+	target := name
+	if target == "" {
+		target = "(" + selector + ")"
+	}
+	if err := checkProtected(gvr, target, args, "changing "+field+" of"); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	ri := dyn.Resource(gvr)
+	var targets []unstructured.Unstructured
+	if name != "" {
+		var obj *unstructured.Unstructured
+		if namespaced {
+			obj, err = ri.Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		} else {
+			obj, err = ri.Get(ctx, name, metav1.GetOptions{})
+		}
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		targets = append(targets, *obj)
+	} else {
+		var list *unstructured.UnstructuredList
+		switch {
+		case !namespaced || allNamespaces:
+			list, err = ri.List(ctx, metav1.ListOptions{LabelSelector: selector})
+		default:
+			list, err = ri.Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		}
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		targets = list.Items
+	}
+
+	var patchOpts metav1.PatchOptions
+	if dryRun {
+		patchOpts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	plans := make([]metadataPlan, 0, len(targets))
+	for i := range targets {
+		obj := &targets[i]
+		var current map[string]string
+		if field == "labels" {
+			current = obj.GetLabels()
+		} else {
+			current = obj.GetAnnotations()
+		}
+
+		plan := metadataPlan{
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			Before:    map[string]string{},
+			After:     map[string]string{},
+		}
+		changes := map[string]any{}
+		var conflicts []string
+		for k, v := range set {
+			old, exists := current[k]
+			if exists {
+				plan.Before[k] = old
+			}
+			plan.After[k] = v
+			if exists && old == v {
+				continue
+			}
+			if exists && !overwrite {
+				conflicts = append(conflicts, fmt.Sprintf("'%s' already has a value (%s)", k, old))
+				continue
+			}
+			changes[k] = v
+		}
+		for _, k := range remove {
+			old, exists := current[k]
+			if !exists {
+				continue
+			}
+			plan.Before[k] = old
+			changes[k] = nil
+		}
+
+		switch {
+		case len(conflicts) > 0:
+			sort.Strings(conflicts)
+			plan.After = plan.Before
+			plan.Status = "error"
+			plan.Message = strings.Join(conflicts, "; ") + ", and overwrite is false"
+			plans = append(plans, plan)
+			continue
+		case len(changes) == 0:
+			plan.Status = "unchanged"
+			plans = append(plans, plan)
+			continue
+		}
+		plan.Changed = true
+
+		patchMeta := map[string]any{field: changes}
+		if resourceVersion != "" {
+			patchMeta["resourceVersion"] = resourceVersion
+		}
+		patch, _ := json.Marshal(map[string]any{"metadata": patchMeta})
+		if namespaced {
+			_, err = ri.Namespace(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.MergePatchType, patch, patchOpts)
+		} else {
+			_, err = ri.Patch(ctx, obj.GetName(), types.MergePatchType, patch, patchOpts)
+		}
+		switch {
+		case err != nil:
+			plan.Status = "error"
+			plan.Message = formatK8sErr(err)
+		case dryRun:
+			plan.Status = "would update"
+		default:
+			plan.Status = "updated"
+		}
+		plans = append(plans, plan)
+	}
+
+	out := map[string]any{
+		"resource": gvr.Resource,
+		"dry_run":  dryRun,
+		"matched":  len(plans),
+		"objects":  plans,
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// parseMetadataChanges accepts either an object (null value removes the key) or
+// kubectl's "k=v,other-" form, and returns the keys to set and to remove. In
+// the string form entries are split on newlines and on commas that start a new
+// entry (see splitMetadataEntries), so values keep their spaces and commas.
+func parseMetadataChanges(v any) (map[string]string, []string, error) {
+	set := map[string]string{}
+	var remove []string
+
+	switch t := v.(type) {
+	case nil:
+	case map[string]any:
+		for k, val := range t {
+			if val == nil {
+				remove = append(remove, k)
+				continue
+			}
+			set[k] = fmtAny(val)
+		}
+	case string:
+		for _, item := range splitMetadataEntries(t) {
+			if k, val, ok := strings.Cut(item, "="); ok {
+				if k == "" {
+					return nil, nil, fmt.Errorf("invalid entry %q", item)
+				}
+				set[k] = val
+				continue
+			}
+			if strings.HasSuffix(item, "-") && len(item) > 1 {
+				remove = append(remove, strings.TrimSuffix(item, "-"))
+				continue
+			}
+			return nil, nil, fmt.Errorf("invalid entry %q (expected key=value or key-)", item)
+		}
+	default:
+		return nil, nil, fmt.Errorf("expected an object or a \"key=value,key-\" string")
+	}
+
+	for _, k := range remove {
+		if _, ok := set[k]; ok {
+			return nil, nil, fmt.Errorf("key '%s' is both set and removed", k)
+		}
+	}
+	sort.Strings(remove)
+	return set, remove, nil
+}
+
+// metadataKeyRe is a label/annotation key, optionally with a DNS prefix.
+var metadataKeyRe = regexp.MustCompile(`^([a-zA-Z0-9.-]+/)?[a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?$`)
+
+// splitMetadataEntries splits "k=v,other-" into entries. A comma only ends an
+// entry when what follows is itself "key=..." or "key-", so
+// "description=hello, world" stays one entry.
+func splitMetadataEntries(s string) []string {
+	var entries []string
+	for _, line := range strings.Split(s, "\n") {
+		var cur []string
+		flush := func() {
+			if e := strings.TrimSpace(strings.Join(cur, ",")); e != "" {
+				entries = append(entries, e)
+			}
+			cur = nil
+		}
+		for _, part := range strings.Split(line, ",") {
+			if len(cur) > 0 && startsMetadataEntry(part) {
+				flush()
+			}
+			cur = append(cur, part)
+		}
+		flush()
+	}
+	return entries
+}
+
+func startsMetadataEntry(s string) bool {
+	s = strings.TrimSpace(s)
+	if k, _, ok := strings.Cut(s, "="); ok {
+		return metadataKeyRe.MatchString(k)
+	}
+	return strings.HasSuffix(s, "-") && metadataKeyRe.MatchString(strings.TrimSuffix(s, "-"))
+}
@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMetadataChangesString(t *testing.T) {
+	tests := []struct {
+		in     string
+		set    map[string]string
+		remove []string
+	}{
+		{"app=web,tier=frontend", map[string]string{"app": "web", "tier": "frontend"}, nil},
+		{"app=web, old-", map[string]string{"app": "web"}, []string{"old"}},
+		{"description=hello world", map[string]string{"description": "hello world"}, nil},
+		{"note=a, b and c,owner=team", map[string]string{"note": "a, b and c", "owner": "team"}, nil},
+		{"example.com/cfg={\"a\":1,\"b\":2}", map[string]string{"example.com/cfg": `{"a":1,"b":2}`}, nil},
+		{"a=1\nb=two words\nc-", map[string]string{"a": "1", "b": "two words"}, []string{"c"}},
+	}
+	for _, tt := range tests {
+		set, remove, err := parseMetadataChanges(tt.in)
+		if err != nil {
+			t.Errorf("parseMetadataChanges(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(set, tt.set) || !reflect.DeepEqual(remove, tt.remove) {
+			t.Errorf("parseMetadataChanges(%q) = %v, %v; want %v, %v", tt.in, set, remove, tt.set, tt.remove)
+		}
+	}
+
+	for _, in := range []string{"=value", "justakey", "a=1,a-"} {
+		if _, _, err := parseMetadataChanges(in); err == nil {
+			t.Errorf("parseMetadataChanges(%q): expected an error", in)
+		}
+	}
+}
@@ -3,20 +3,35 @@ package tools
 import (
 	"bufio"
 	"context"
+	"fmt"
 	"io"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 // K8sLogs ports logs.py k8s_logs(...)
-func K8sLogs(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+//
+// Multi-container extras (not with follow):
+// - all_containers (bool): logs of every container, one section each
+// - container_pattern: regex on container names (e.g. "^app-"), alone or narrowing all_containers
+//
+// since_last_restart (bool) starts each container's logs at the start of its
+// current run (with previous, of the previous run), read from
+// containerStatuses; without a recorded start time the full log is returned.
+// It cannot be combined with since.
+//
+// With follow over an HTTP transport, lines are also sent as progress
+// notifications as they arrive when the call carries a progressToken.
+func K8sLogs(ctx context.Context, callReq *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	podName, _ := args["pod_name"].(string)
 	if strings.TrimSpace(podName) == "" {
 		return textErrorResult("pod_name is required"), nil, nil
@@ -25,26 +40,44 @@ func K8sLogs(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (
 	container, _ := args["container"].(string)
 	namespace, _ := args["namespace"].(string)
 	if namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	previous := boolFromArgs(args, "previous", false)
 	timestamps := boolFromArgs(args, "timestamps", false)
 	follow := boolFromArgs(args, "follow", false)
+	allContainers := boolFromArgs(args, "all_containers", false)
+	containerPattern := getStringArg(args, "container_pattern")
+	sinceLastRestart := boolFromArgs(args, "since_last_restart", false)
 
-	var tailLinesPtr *int64
-	if tail, ok := intFromArgs(args, "tail"); ok {
-		if tail > 0 {
-			t := int64(tail)
-			tailLinesPtr = &t
+	var containerRe *regexp.Regexp
+	if containerPattern != "" {
+		re, err := regexp.Compile(containerPattern)
+		if err != nil {
+			return textErrorResult(fmt.Sprintf("Error: invalid container_pattern %q: %v", containerPattern, err)), nil, nil
 		}
+		containerRe = re
+	}
+	multi := allContainers || containerRe != nil
+	if multi && container != "" {
+		return textErrorResult("Error: container cannot be combined with all_containers or container_pattern"), nil, nil
+	}
+	if multi && follow {
+		return textErrorResult("Error: follow supports a single container; drop all_containers/container_pattern or pick a container"), nil, nil
 	}
 
-	var sinceSecondsPtr *int64
-	if since, ok := args["since"].(string); ok && strings.TrimSpace(since) != "" {
-		if ss := parseSinceSeconds(since); ss != nil {
-			sinceSecondsPtr = ss
-		}
+	tailLinesPtr, sinceSecondsPtr, err := logWindowFromArgs(args)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+	if sinceLastRestart && sinceSecondsPtr != nil {
+		return textErrorResult("Error: since cannot be combined with since_last_restart"), nil, nil
+	}
+	if !follow {
+		// follow streams until its own cap; a plain read gets the tool deadline.
+		var cancel context.CancelFunc
+		ctx, cancel = toolContext(ctx)
+		defer cancel()
 	}
 
 	cs, err := getClient()
@@ -58,11 +91,39 @@ func K8sLogs(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (
 		return textErrorResult(formatK8sErr(err)), nil, nil
 	}
 
-	// Default container to first container
+	if multi {
+		names := matchContainers(pod, containerRe)
+		if len(names) == 0 {
+			return textErrorResult(fmt.Sprintf("Error: no container in pod '%s' matches %q", podName, containerPattern)), nil, nil
+		}
+		var sb strings.Builder
+		sb.WriteString("Containers: " + strings.Join(names, ", ") + "\n")
+		for _, name := range names {
+			opts := &v1.PodLogOptions{
+				Container:    name,
+				Previous:     previous,
+				Timestamps:   timestamps,
+				TailLines:    tailLinesPtr,
+				SinceSeconds: sinceSecondsPtr,
+			}
+			if sinceLastRestart {
+				opts.SinceTime = containerRunStart(pod, name, previous)
+			}
+			b, err := cs.CoreV1().Pods(namespace).GetLogs(podName, opts).DoRaw(ctx)
+			sb.WriteString("\n==> " + name + " <==\n")
+			if err != nil {
+				sb.WriteString(formatLogErr(err) + "\n")
+				continue
+			}
+			sb.Write(b)
+		}
+		return textOKResult(sb.String()), nil, nil
+	}
+
+	// Default container: the default-container annotation, else the first container
 	if container == "" {
-		if pod.Spec.Containers != nil && len(pod.Spec.Containers) > 0 {
-			container = pod.Spec.Containers[0].Name
-		} else {
+		container = podDefaultContainerName(pod)
+		if container == "" {
 			return textErrorResult("Error: No containers found in pod"), nil, nil
 		}
 	}
@@ -75,6 +136,9 @@ func K8sLogs(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (
 		TailLines:    tailLinesPtr,
 		SinceSeconds: sinceSecondsPtr,
 	}
+	if sinceLastRestart {
+		opts.SinceTime = containerRunStart(pod, container, previous)
+	}
 
 	req := cs.CoreV1().Pods(namespace).GetLogs(podName, opts)
 
@@ -95,7 +159,9 @@ func K8sLogs(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (
 	}
 	defer rc.Close()
 
-	const maxBytes = 1024 * 1024
+	maxBytes := streamOutputCap()
+	progress := newProgressStreamer(ctx, callReq)
+	defer progress.close()
 
 	var sb strings.Builder
 	sb.Grow(16 * 1024)
@@ -109,11 +175,14 @@ func K8sLogs(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (
 				remaining := maxBytes - sb.Len()
 				if remaining > 0 {
 					sb.Write(line[:remaining])
+					progress.write(string(line[:remaining]))
 				}
-				sb.WriteString("\n... log output truncated ...\n")
+				sb.WriteString(truncatedMarker(-1))
+				progress.write(truncatedMarker(-1))
 				break
 			}
 			sb.Write(line)
+			progress.write(string(line))
 		}
 
 		if readErr != nil {
@@ -127,6 +196,91 @@ func K8sLogs(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (
 	return textOKResult(sb.String()), nil, nil
 }
 
+// matchContainers returns the pod's init and regular container names, in spec
+// order, that match re (all of them when re is nil).
+func matchContainers(pod *v1.Pod, re *regexp.Regexp) []string {
+	var names []string
+	for _, list := range [][]v1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, c := range list {
+			if re == nil || re.MatchString(c.Name) {
+				names = append(names, c.Name)
+			}
+		}
+	}
+	return names
+}
+
+// containerRunStart is when the run whose logs GetLogs returns started: the
+// running or terminated instance, or for a waiting (crash-looping) container
+// the last terminated one; with previous, the previous instance. nil when the
+// status records no start time.
+func containerRunStart(pod *v1.Pod, container string, previous bool) *metav1.Time {
+	statuses := append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, st := range statuses {
+		if st.Name != container {
+			continue
+		}
+		var t metav1.Time
+		switch {
+		case previous:
+			if st.LastTerminationState.Terminated != nil {
+				t = st.LastTerminationState.Terminated.StartedAt
+			}
+		case st.State.Running != nil:
+			t = st.State.Running.StartedAt
+		case st.State.Terminated != nil:
+			t = st.State.Terminated.StartedAt
+		case st.LastTerminationState.Terminated != nil:
+			t = st.LastTerminationState.Terminated.StartedAt
+		}
+		if t.IsZero() {
+			return nil
+		}
+		return &t
+	}
+	return nil
+}
+
+// maxLogTailLines caps tail so a typo like tail=1e9 can't pull a whole log file.
+const maxLogTailLines = 10000
+
+// logWindowFromArgs validates tail and since. Both may be set together: the API
+// applies since first and then returns the last tail lines of that window.
+// tail <= 0 means no tail limit.
+func logWindowFromArgs(args map[string]any) (*int64, *int64, error) {
+	var tailLines *int64
+	if raw, present := args["tail"]; present && raw != nil {
+		tail, ok := intFromArgs(args, "tail")
+		if !ok {
+			return nil, nil, fmt.Errorf("tail must be an integer, got %v", raw)
+		}
+		if tail > maxLogTailLines {
+			tail = maxLogTailLines
+		}
+		if tail > 0 {
+			t := int64(tail)
+			tailLines = &t
+		}
+	}
+
+	var sinceSeconds *int64
+	if since, _ := args["since"].(string); strings.TrimSpace(since) != "" {
+		ss := parseSinceSeconds(since)
+		if ss == nil {
+			return nil, nil, fmt.Errorf("invalid since %q (expected e.g. 30s, 5m, 2h, 1d or an RFC3339 timestamp)", since)
+		}
+		// The API rejects sinceSeconds < 1 (e.g. a timestamp in the future).
+		if *ss < 1 {
+			one := int64(1)
+			ss = &one
+		}
+		sinceSeconds = ss
+	}
+
+	return tailLines, sinceSeconds, nil
+}
+
 func formatLogErr(err error) string {
 	// Try to keep errors human-ish like python's ApiException str()
 	// If it's a StatusError it will include useful details.
@@ -194,3 +348,55 @@ func parseSinceSeconds(since string) *int64 {
 	}
 	return &secs
 }
+
+// podLogTarget is one container whose log goes into an aggregated view.
+type podLogTarget struct {
+	pod       *v1.Pod
+	container string
+}
+
+// aggregatePodLogs fetches the targets' logs concurrently and joins them in
+// target order, one "==> namespace/pod [container] <==" section each. The
+// result is capped at streamOutputCap.
+func aggregatePodLogs(ctx context.Context, cs *kubernetes.Clientset, targets []podLogTarget, opts v1.PodLogOptions) string {
+	sections := make([]string, len(targets))
+	var wg sync.WaitGroup
+	tokens := make(chan struct{}, getAllConcurrency)
+	for i := range targets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens <- struct{}{}
+			defer func() { <-tokens }()
+
+			t := targets[i]
+			o := opts
+			o.Container = t.container
+			header := fmt.Sprintf("==> %s/%s [%s] <==\n", t.pod.Namespace, t.pod.Name, t.container)
+			b, err := cs.CoreV1().Pods(t.pod.Namespace).GetLogs(t.pod.Name, &o).DoRaw(ctx)
+			if err != nil {
+				sections[i] = header + formatLogErr(err) + "\n"
+				return
+			}
+			sections[i] = header + string(b)
+		}(i)
+	}
+	wg.Wait()
+
+	maxBytes := streamOutputCap()
+	var sb strings.Builder
+	for _, s := range sections {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		if sb.Len()+len(s) > maxBytes {
+			if remaining := maxBytes - sb.Len(); remaining > 0 {
+				sb.WriteString(s[:remaining])
+			}
+			sb.WriteString(truncatedMarker(-1))
+			break
+		}
+		sb.WriteString(s)
+	}
+	return sb.String()
+}
@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// applyFieldManager is the field manager used for server-side apply.
+const applyFieldManager = "mcp-k8s"
+
+// maxOwnershipPaths bounds the path lists in an ownership summary.
+const maxOwnershipPaths = 50
+
+// fieldOwnership summarizes metadata.managedFields after a server-side apply.
+type fieldOwnership struct {
+	Manager       string              `json:"manager"`
+	OwnedCount    int                 `json:"owned_count"`
+	Owned         []string            `json:"owned,omitempty"`
+	TakenFrom     map[string][]string `json:"taken_from,omitempty"`
+	OtherManagers map[string]int      `json:"other_managers,omitempty"`
+	Truncated     bool                `json:"truncated,omitempty"`
+}
+
+// ownershipSummary reports what applyFieldManager owns in after and, when the
+// pre-apply object is known, which of those fields other managers owned before
+// (i.e. were taken over by a forced apply). other_managers maps each remaining
+// manager to the number of fields it still owns.
+func ownershipSummary(before, after *unstructured.Unstructured) *fieldOwnership {
+	afterSets := managedFieldSets(after)
+	mine := afterSets[applyFieldManager]
+	if mine == nil {
+		return nil
+	}
+
+	s := &fieldOwnership{Manager: applyFieldManager, OwnedCount: len(mine)}
+	s.Owned = sortedLimited(mine, &s.Truncated)
+
+	for m, set := range afterSets {
+		if m == applyFieldManager {
+			continue
+		}
+		if s.OtherManagers == nil {
+			s.OtherManagers = map[string]int{}
+		}
+		s.OtherManagers[m] = len(set)
+	}
+
+	if before != nil {
+		for m, set := range managedFieldSets(before) {
+			if m == applyFieldManager {
+				continue
+			}
+			taken := map[string]bool{}
+			for p := range set {
+				if mine[p] && !afterSets[m][p] {
+					taken[p] = true
+				}
+			}
+			if len(taken) > 0 {
+				if s.TakenFrom == nil {
+					s.TakenFrom = map[string][]string{}
+				}
+				s.TakenFrom[m] = sortedLimited(taken, &s.Truncated)
+			}
+		}
+	}
+	return s
+}
+
+// managedFieldSets maps manager name to the leaf field paths it owns, merging
+// a manager's Apply and Update entries.
+func managedFieldSets(obj *unstructured.Unstructured) map[string]map[string]bool {
+	out := map[string]map[string]bool{}
+	if obj == nil {
+		return out
+	}
+	for _, mf := range obj.GetManagedFields() {
+		if mf.FieldsV1 == nil {
+			continue
+		}
+		var tree map[string]any
+		if err := json.Unmarshal(mf.FieldsV1.Raw, &tree); err != nil {
+			continue
+		}
+		set := out[mf.Manager]
+		if set == nil {
+			set = map[string]bool{}
+			out[mf.Manager] = set
+		}
+		flattenFieldsV1(tree, "", set)
+	}
+	return out
+}
+
+// flattenFieldsV1 turns the FieldsV1 trie ("f:spec" -> "f:replicas" -> {}) into
+// dotted paths; list items keep their key, e.g. spec.containers[{"name":"app"}].image.
+func flattenFieldsV1(tree map[string]any, prefix string, set map[string]bool) {
+	leaf := true
+	for k, v := range tree {
+		if k == "." {
+			continue
+		}
+		leaf = false
+		var p string
+		switch {
+		case strings.HasPrefix(k, "f:"):
+			p = k[2:]
+			if prefix != "" {
+				p = prefix + "." + p
+			}
+		case strings.HasPrefix(k, "k:"), strings.HasPrefix(k, "v:"), strings.HasPrefix(k, "i:"):
+			p = prefix + "[" + k[2:] + "]"
+		default:
+			p = prefix + "." + k
+		}
+		child, _ := v.(map[string]any)
+		flattenFieldsV1(child, p, set)
+	}
+	if leaf && prefix != "" {
+		set[prefix] = true
+	}
+}
+
+func sortedLimited(set map[string]bool, truncated *bool) []string {
+	paths := make([]string, 0, len(set))
+	for p := range set {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	if len(paths) > maxOwnershipPaths {
+		paths = paths[:maxOwnershipPaths]
+		*truncated = true
+	}
+	return paths
+}
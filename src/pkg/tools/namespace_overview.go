@@ -0,0 +1,244 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type quotaUsage struct {
+	Name      string            `json:"name"`
+	Resources map[string]string `json:"resources"` // "used/hard"
+	Exhausted []string          `json:"exhausted,omitempty"`
+}
+
+// K8sNamespaceOverview is an at-a-glance view of one namespace: status, labels
+// and annotations, ResourceQuota usage, counts of common objects (pods by phase)
+// and, for a terminating namespace, what is holding it up. The lists run
+// concurrently; one that fails is reported under errors without failing the rest.
+//
+// Args: namespace defaults to defaultNamespace()
+func K8sNamespaceOverview(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	namespace := getStringArg(args, "namespace", "name")
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	ns, err := cs.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	out := map[string]any{
+		"namespace":   ns.Name,
+		"phase":       string(ns.Status.Phase),
+		"created":     formatMetaTime(ns.CreationTimestamp),
+		"labels":      ns.Labels,
+		"annotations": ns.Annotations,
+	}
+	if ns.DeletionTimestamp != nil {
+		out["terminating"] = namespaceTermination(ns)
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		counts = map[string]int{}
+		errs   = map[string]string{}
+	)
+	run := func(key string, f func() (any, error)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := f()
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[key] = formatK8sErr(err)
+				return
+			}
+			if n, ok := v.(int); ok {
+				counts[key] = n
+			} else {
+				out[key] = v
+			}
+		}()
+	}
+
+	run("pods", func() (any, error) {
+		l, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		phases := map[string]int{}
+		for i := range l.Items {
+			phases[string(l.Items[i].Status.Phase)]++
+		}
+		mu.Lock()
+		out["pod_phases"] = phases
+		mu.Unlock()
+		return len(l.Items), nil
+	})
+	run("resource_quotas", func() (any, error) {
+		return namespaceQuotas(ctx, cs, namespace)
+	})
+	for key, list := range namespaceCounters(cs, namespace) {
+		list := list
+		run(key, func() (any, error) { return list(ctx) })
+	}
+	wg.Wait()
+
+	out["counts"] = counts
+	if len(errs) > 0 {
+		out["errors"] = errs
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// namespaceCounters lists the common object kinds and returns their counts.
+func namespaceCounters(cs *kubernetes.Clientset, ns string) map[string]func(context.Context) (any, error) {
+	lo := metav1.ListOptions{}
+	return map[string]func(context.Context) (any, error){
+		"deployments": func(ctx context.Context) (any, error) {
+			l, err := cs.AppsV1().Deployments(ns).List(ctx, lo)
+			if err != nil {
+				return nil, err
+			}
+			return len(l.Items), nil
+		},
+		"statefulsets": func(ctx context.Context) (any, error) {
+			l, err := cs.AppsV1().StatefulSets(ns).List(ctx, lo)
+			if err != nil {
+				return nil, err
+			}
+			return len(l.Items), nil
+		},
+		"daemonsets": func(ctx context.Context) (any, error) {
+			l, err := cs.AppsV1().DaemonSets(ns).List(ctx, lo)
+			if err != nil {
+				return nil, err
+			}
+			return len(l.Items), nil
+		},
+		"jobs": func(ctx context.Context) (any, error) {
+			l, err := cs.BatchV1().Jobs(ns).List(ctx, lo)
+			if err != nil {
+				return nil, err
+			}
+			return len(l.Items), nil
+		},
+		"cronjobs": func(ctx context.Context) (any, error) {
+			l, err := cs.BatchV1().CronJobs(ns).List(ctx, lo)
+			if err != nil {
+				return nil, err
+			}
+			return len(l.Items), nil
+		},
+		"services": func(ctx context.Context) (any, error) {
+			l, err := cs.CoreV1().Services(ns).List(ctx, lo)
+			if err != nil {
+				return nil, err
+			}
+			return len(l.Items), nil
+		},
+		"ingresses": func(ctx context.Context) (any, error) {
+			l, err := cs.NetworkingV1().Ingresses(ns).List(ctx, lo)
+			if err != nil {
+				return nil, err
+			}
+			return len(l.Items), nil
+		},
+		"configmaps": func(ctx context.Context) (any, error) {
+			l, err := cs.CoreV1().ConfigMaps(ns).List(ctx, lo)
+			if err != nil {
+				return nil, err
+			}
+			return len(l.Items), nil
+		},
+		"secrets": func(ctx context.Context) (any, error) {
+			l, err := cs.CoreV1().Secrets(ns).List(ctx, lo)
+			if err != nil {
+				return nil, err
+			}
+			return len(l.Items), nil
+		},
+		"persistentvolumeclaims": func(ctx context.Context) (any, error) {
+			l, err := cs.CoreV1().PersistentVolumeClaims(ns).List(ctx, lo)
+			if err != nil {
+				return nil, err
+			}
+			return len(l.Items), nil
+		},
+		"serviceaccounts": func(ctx context.Context) (any, error) {
+			l, err := cs.CoreV1().ServiceAccounts(ns).List(ctx, lo)
+			if err != nil {
+				return nil, err
+			}
+			return len(l.Items), nil
+		},
+	}
+}
+
+// namespaceQuotas reports each ResourceQuota as "used/hard" per resource and
+// flags the resources whose usage has reached the hard limit.
+func namespaceQuotas(ctx context.Context, cs *kubernetes.Clientset, ns string) ([]quotaUsage, error) {
+	l, err := cs.CoreV1().ResourceQuotas(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	quotas := []quotaUsage{}
+	for _, q := range l.Items {
+		u := quotaUsage{Name: q.Name, Resources: map[string]string{}}
+		for r, hard := range q.Status.Hard {
+			used := q.Status.Used[r]
+			u.Resources[string(r)] = used.String() + "/" + hard.String()
+			if used.Cmp(hard) >= 0 {
+				u.Exhausted = append(u.Exhausted, string(r))
+			}
+		}
+		sort.Strings(u.Exhausted)
+		quotas = append(quotas, u)
+	}
+	return quotas, nil
+}
+
+// namespaceTermination explains a stuck deletion: the finalizers still set and
+// the namespace controller's True conditions (remaining content, failed
+// discovery, finalizers on remaining objects, ...).
+func namespaceTermination(ns *v1.Namespace) map[string]any {
+	t := map[string]any{
+		"since": formatMetaTime(*ns.DeletionTimestamp),
+	}
+	var finalizers []string
+	for _, f := range ns.Spec.Finalizers {
+		finalizers = append(finalizers, string(f))
+	}
+	finalizers = append(finalizers, ns.Finalizers...)
+	if len(finalizers) > 0 {
+		t["finalizers"] = finalizers
+	}
+	var blocking []string
+	for _, c := range ns.Status.Conditions {
+		if c.Status == v1.ConditionTrue {
+			blocking = append(blocking, strings.TrimSpace(string(c.Type)+": "+c.Message))
+		}
+	}
+	if len(blocking) > 0 {
+		t["blocking"] = blocking
+	}
+	return t
+}
@@ -0,0 +1,438 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+type serviceEndpoint struct {
+	Address string `json:"address"`
+	Ready   bool   `json:"ready"`
+	Pod     string `json:"pod,omitempty"`
+	Node    string `json:"node,omitempty"`
+}
+
+type servicePort struct {
+	Name     string `json:"name,omitempty"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// K8sServiceEndpoints answers "is my service routing to pods": it resolves a
+// Service to its backing addresses from EndpointSlices (discovery.k8s.io/v1),
+// falling back to the legacy Endpoints object.
+//
+// Args: name required; namespace defaults to defaultNamespace()
+func K8sServiceEndpoints(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	name, _ := args["name"].(string)
+	namespace, _ := args["namespace"].(string)
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	svc, err := cs.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	var (
+		endpoints []serviceEndpoint
+		ports     []servicePort
+		source    string
+	)
+
+	slices, err := cs.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + name,
+	})
+	switch {
+	case err == nil && len(slices.Items) > 0:
+		source = "endpointslices"
+		endpoints, ports = endpointsFromSlices(slices.Items)
+	case err != nil && !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err):
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	default:
+		ep, err := cs.CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		if err == nil {
+			source = "endpoints"
+			endpoints, ports = endpointsFromLegacy(ep)
+		}
+	}
+
+	ready := 0
+	for _, e := range endpoints {
+		if e.Ready {
+			ready++
+		}
+	}
+
+	out := map[string]any{
+		"service":         name,
+		"namespace":       namespace,
+		"type":            string(svc.Spec.Type),
+		"selector":        svc.Spec.Selector,
+		"source":          source,
+		"ports":           ports,
+		"endpoints":       endpoints,
+		"ready_count":     ready,
+		"not_ready_count": len(endpoints) - ready,
+	}
+	switch {
+	case svc.Spec.Type == v1.ServiceTypeExternalName:
+		out["note"] = "ExternalName service (" + svc.Spec.ExternalName + "); it has no endpoints"
+	case len(svc.Spec.Selector) == 0 && len(endpoints) == 0:
+		out["note"] = "service has no selector and no manually managed endpoints"
+	case len(endpoints) == 0:
+		out["note"] = "no pods match the service selector"
+	case ready == 0:
+		out["note"] = "matching pods exist but none are ready; traffic is not being routed"
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+func endpointsFromSlices(slices []discoveryv1.EndpointSlice) ([]serviceEndpoint, []servicePort) {
+	endpoints := []serviceEndpoint{}
+	var ports []servicePort
+	seenPort := map[string]bool{}
+
+	for _, s := range slices {
+		for _, p := range s.Ports {
+			sp := servicePort{}
+			if p.Name != nil {
+				sp.Name = *p.Name
+			}
+			if p.Port != nil {
+				sp.Port = *p.Port
+			}
+			if p.Protocol != nil {
+				sp.Protocol = string(*p.Protocol)
+			}
+			key := fmt.Sprintf("%s/%d/%s", sp.Name, sp.Port, sp.Protocol)
+			if !seenPort[key] {
+				seenPort[key] = true
+				ports = append(ports, sp)
+			}
+		}
+		for _, e := range s.Endpoints {
+			// A nil Ready condition means "unknown", which consumers treat as ready.
+			ready := e.Conditions.Ready == nil || *e.Conditions.Ready
+			for _, addr := range e.Addresses {
+				se := serviceEndpoint{Address: addr, Ready: ready}
+				if e.TargetRef != nil && e.TargetRef.Kind == "Pod" {
+					se.Pod = e.TargetRef.Name
+				}
+				if e.NodeName != nil {
+					se.Node = *e.NodeName
+				}
+				endpoints = append(endpoints, se)
+			}
+		}
+	}
+	sortEndpoints(endpoints)
+	return endpoints, ports
+}
+
+func endpointsFromLegacy(ep *v1.Endpoints) ([]serviceEndpoint, []servicePort) {
+	endpoints := []serviceEndpoint{}
+	var ports []servicePort
+	seenPort := map[string]bool{}
+
+	add := func(addrs []v1.EndpointAddress, ready bool) {
+		for _, a := range addrs {
+			se := serviceEndpoint{Address: a.IP, Ready: ready}
+			if a.TargetRef != nil && a.TargetRef.Kind == "Pod" {
+				se.Pod = a.TargetRef.Name
+			}
+			if a.NodeName != nil {
+				se.Node = *a.NodeName
+			}
+			endpoints = append(endpoints, se)
+		}
+	}
+	for _, subset := range ep.Subsets {
+		add(subset.Addresses, true)
+		add(subset.NotReadyAddresses, false)
+		for _, p := range subset.Ports {
+			key := fmt.Sprintf("%s/%d/%s", p.Name, p.Port, p.Protocol)
+			if !seenPort[key] {
+				seenPort[key] = true
+				ports = append(ports, servicePort{Name: p.Name, Port: p.Port, Protocol: string(p.Protocol)})
+			}
+		}
+	}
+	sortEndpoints(endpoints)
+	return endpoints, ports
+}
+
+func sortEndpoints(eps []serviceEndpoint) {
+	sort.SliceStable(eps, func(i, j int) bool {
+		if eps[i].Ready != eps[j].Ready {
+			return eps[i].Ready
+		}
+		return eps[i].Address < eps[j].Address
+	})
+}
+
+type ingressRoute struct {
+	Namespace string `json:"namespace"`
+	Ingress   string `json:"ingress"`
+	Class     string `json:"class,omitempty"`
+	Host      string `json:"host"`
+	Path      string `json:"path"`
+	PathType  string `json:"path_type,omitempty"`
+	Backend   string `json:"backend"`
+	TLS       bool   `json:"tls"`
+}
+
+// K8sIngressRoutes flattens networking.k8s.io/v1 Ingresses into
+// host/path -> service:port rows. Default backends are listed with host "*" and
+// path "(default)".
+//
+// Args: namespace defaults to defaultNamespace(); all_namespaces (bool)
+func K8sIngressRoutes(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	namespace, _ := args["namespace"].(string)
+	if boolFromArgs(args, "all_namespaces", false) {
+		namespace = metav1.NamespaceAll
+	} else if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	list, err := cs.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	routes := []ingressRoute{}
+	for _, ing := range list.Items {
+		class := ""
+		if ing.Spec.IngressClassName != nil {
+			class = *ing.Spec.IngressClassName
+		} else if c := ing.Annotations["kubernetes.io/ingress.class"]; c != "" {
+			class = c
+		}
+
+		tlsHosts := map[string]bool{}
+		for _, t := range ing.Spec.TLS {
+			for _, h := range t.Hosts {
+				tlsHosts[h] = true
+			}
+		}
+
+		base := ingressRoute{Namespace: ing.Namespace, Ingress: ing.Name, Class: class}
+		if ing.Spec.DefaultBackend != nil {
+			r := base
+			r.Host = "*"
+			r.Path = "(default)"
+			r.Backend = ingressBackendString(*ing.Spec.DefaultBackend)
+			routes = append(routes, r)
+		}
+		for _, rule := range ing.Spec.Rules {
+			host := rule.Host
+			if host == "" {
+				host = "*"
+			}
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, p := range rule.HTTP.Paths {
+				r := base
+				r.Host = host
+				r.Path = p.Path
+				if r.Path == "" {
+					r.Path = "/"
+				}
+				if p.PathType != nil {
+					r.PathType = string(*p.PathType)
+				}
+				r.Backend = ingressBackendString(p.Backend)
+				r.TLS = tlsHosts[rule.Host]
+				routes = append(routes, r)
+			}
+		}
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		a, b := routes[i], routes[j]
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		return a.Path < b.Path
+	})
+
+	b, _ := json.MarshalIndent(routes, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+func ingressBackendString(b networkingv1.IngressBackend) string {
+	if b.Service != nil {
+		port := b.Service.Port.Name
+		if port == "" {
+			port = fmt.Sprint(b.Service.Port.Number)
+		}
+		return "service/" + b.Service.Name + ":" + port
+	}
+	if b.Resource != nil {
+		return b.Resource.Kind + "/" + b.Resource.Name
+	}
+	return ""
+}
+
+// K8sWaitLoadBalancer watches a LoadBalancer Service until the cloud provider
+// fills in status.loadBalancer.ingress, then returns the addresses. On timeout
+// it returns the pending state and the Service's events (e.g.
+// SyncLoadBalancerFailed) instead of an error.
+//
+// Args: name required; namespace defaults to defaultNamespace(); timeout seconds, default 300, max 1800
+func K8sWaitLoadBalancer(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	name := getStringArg(args, "name")
+	namespace := getStringArg(args, "namespace")
+	timeout := intFromArgsDefault(args, "timeout", 300)
+
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	if timeout <= 0 {
+		timeout = 300
+	}
+	if timeout > 1800 {
+		timeout = 1800
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	svc, err := cs.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	if svc.Spec.Type != v1.ServiceTypeLoadBalancer {
+		return textErrorResult(fmt.Sprintf("Error: service '%s' is of type %s, not LoadBalancer; it will never get an external address", name, svc.Spec.Type)), nil, nil
+	}
+
+	start := time.Now()
+	wctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	rv := svc.ResourceVersion
+	// resync re-reads the Service after a failed or expired watch and pauses
+	// briefly, so a stale resourceVersion can't turn into a tight re-watch loop.
+	resync := func() {
+		if s, err := cs.CoreV1().Services(namespace).Get(wctx, name, metav1.GetOptions{}); err == nil {
+			svc, rv = s, s.ResourceVersion
+		}
+		select {
+		case <-wctx.Done():
+		case <-time.After(2 * time.Second):
+		}
+	}
+	for len(svc.Status.LoadBalancer.Ingress) == 0 && wctx.Err() == nil {
+		w, err := cs.CoreV1().Services(namespace).Watch(wctx, metav1.ListOptions{
+			FieldSelector:   "metadata.name=" + name,
+			ResourceVersion: rv,
+		})
+		if err != nil {
+			// Expired resourceVersion or a transient error: re-read and retry.
+			resync()
+			continue
+		}
+		watchFailed := false
+		for ev := range w.ResultChan() {
+			if ev.Type == watch.Error {
+				// e.g. 410 Gone: rv is too old for the watch cache.
+				watchFailed = true
+				break
+			}
+			s, ok := ev.Object.(*v1.Service)
+			if !ok {
+				continue
+			}
+			if ev.Type == watch.Deleted {
+				w.Stop()
+				return textErrorResult(fmt.Sprintf("Error: service '%s' was deleted while waiting", name)), nil, nil
+			}
+			svc, rv = s, s.ResourceVersion
+			if len(svc.Status.LoadBalancer.Ingress) > 0 {
+				break
+			}
+		}
+		w.Stop()
+		if watchFailed {
+			resync()
+		}
+	}
+
+	out := map[string]any{
+		"service":   name,
+		"namespace": namespace,
+		"waited":    time.Since(start).Round(time.Second).String(),
+	}
+	if len(svc.Status.LoadBalancer.Ingress) > 0 {
+		var addrs []string
+		for _, ing := range svc.Status.LoadBalancer.Ingress {
+			if ing.IP != "" {
+				addrs = append(addrs, ing.IP)
+			}
+			if ing.Hostname != "" {
+				addrs = append(addrs, ing.Hostname)
+			}
+		}
+		out["ready"] = true
+		out["addresses"] = addrs
+		var ports []string
+		for _, p := range svc.Spec.Ports {
+			ports = append(ports, fmt.Sprintf("%d/%s", p.Port, p.Protocol))
+		}
+		out["ports"] = ports
+	} else {
+		out["ready"] = false
+		out["state"] = "pending: no ingress address assigned yet"
+		obj := &unstructured.Unstructured{}
+		obj.SetName(name)
+		obj.SetNamespace(namespace)
+		var events []string
+		for _, e := range fetchEventsForObject(ctx, cs, obj) {
+			events = append(events, fmt.Sprintf("%s %s %s: %s", formatEventTime(e), e.Type, e.Reason, e.Message))
+		}
+		if len(events) > 0 {
+			out["events"] = events
+		}
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
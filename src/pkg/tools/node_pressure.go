@@ -0,0 +1,263 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type nodeCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"last_transition_time,omitempty"`
+}
+
+// nodeResourceLine is allocatable vs what the node's pods request, limit and use.
+type nodeResourceLine struct {
+	Resource      string `json:"resource"`
+	Allocatable   string `json:"allocatable"`
+	Requested     string `json:"requested"`
+	RequestedPct  *int64 `json:"requested_pct,omitempty"`
+	Limits        string `json:"limits,omitempty"`
+	LimitsPct     *int64 `json:"limits_pct,omitempty"`
+	Usage         string `json:"usage,omitempty"`
+	UsagePct      *int64 `json:"usage_pct,omitempty"`
+	Overcommitted bool   `json:"overcommitted,omitempty"`
+}
+
+type evictionCandidate struct {
+	Namespace      string `json:"namespace"`
+	Name           string `json:"name"`
+	QOSClass       string `json:"qos_class"`
+	Priority       int32  `json:"priority"`
+	MemoryUsage    string `json:"memory_usage,omitempty"`
+	MemoryRequest  string `json:"memory_request,omitempty"`
+	ExceedsRequest bool   `json:"exceeds_request"`
+	Reason         string `json:"reason"`
+
+	hasUsage bool
+	over     int64
+}
+
+// K8sNodePressure reports whether a node is under (or close to) resource
+// pressure and which pods the kubelet would evict first.
+//
+// The node's MemoryPressure/DiskPressure/PIDPressure conditions are listed with
+// allocatable vs the summed requests/limits of its non-terminated pods and, when
+// metrics.k8s.io is available, current usage. Eviction candidates follow the
+// kubelet's memory ranking: pods using more than they request first, then lower
+// priority, then by how far usage exceeds the request. Without metrics the
+// ranking falls back to QoS class (BestEffort, Burstable, Guaranteed) and priority.
+//
+// Args:
+// - node_name (required)
+// - limit: number of eviction candidates (default 10)
+func K8sNodePressure(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	nodeName := getStringArg(args, "node_name", "name", "node")
+	if strings.TrimSpace(nodeName) == "" {
+		return textErrorResult("node_name is required"), nil, nil
+	}
+	limit := intFromArgsDefault(args, "limit", 10)
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	node, err := cs.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	podList, err := cs.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: "spec.nodeName=" + nodeName})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	var notes []string
+	underPressure := []string{}
+	conditions := []nodeCondition{}
+	for _, c := range node.Status.Conditions {
+		switch c.Type {
+		case v1.NodeReady, v1.NodeMemoryPressure, v1.NodeDiskPressure, v1.NodePIDPressure:
+		default:
+			continue
+		}
+		if c.Type != v1.NodeReady && c.Status == v1.ConditionTrue {
+			underPressure = append(underPressure, string(c.Type))
+		}
+		conditions = append(conditions, nodeCondition{
+			Type:               string(c.Type),
+			Status:             string(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: formatMetaTime(c.LastTransitionTime),
+		})
+	}
+
+	// Node usage; a missing metrics API is reported, not an error.
+	var nodeCPU, nodeMem *resource.Quantity
+	nodeMetricsGVR := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+	if m, err := dyn.Resource(nodeMetricsGVR).Get(ctx, nodeName, metav1.GetOptions{}); err != nil {
+		notes = append(notes, "node metrics unavailable: "+formatK8sErr(err))
+	} else if c, me, ok := extractNodeUsage(m); ok {
+		nodeCPU, nodeMem = &c, &me
+	}
+	podMetrics, err := podMetricsByKey(ctx, dyn, "", true)
+	if err != nil {
+		notes = append(notes, "pod metrics unavailable, eviction ranking uses QoS class and priority only")
+	}
+
+	requested := v1.ResourceList{}
+	limits := v1.ResourceList{}
+	candidates := []evictionCandidate{}
+	running := 0
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if isCompletedPod(pod) {
+			continue
+		}
+		running++
+		req, lim := podRequestsAndLimits(&pod.Spec)
+		for r, q := range req {
+			cur := requested[r]
+			cur.Add(q)
+			requested[r] = cur
+		}
+		for r, q := range lim {
+			cur := limits[r]
+			cur.Add(q)
+			limits[r] = cur
+		}
+
+		// Mirror (static) pods are never evicted by the kubelet.
+		if isMirrorPod(pod) {
+			continue
+		}
+		c := evictionCandidate{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			QOSClass:  string(podQOSClass(pod)),
+		}
+		if pod.Spec.Priority != nil {
+			c.Priority = *pod.Spec.Priority
+		}
+		memReq := req[v1.ResourceMemory]
+		if !memReq.IsZero() {
+			c.MemoryRequest = formatBytesHuman(memReq.Value())
+		}
+		if m, ok := podMetrics[pod.Namespace+"/"+pod.Name]; ok {
+			if _, bytes, ok := sumPodUsage(m); ok {
+				c.hasUsage = true
+				c.MemoryUsage = formatBytesHuman(bytes)
+				c.over = bytes - memReq.Value()
+				c.ExceedsRequest = c.over > 0
+			}
+		}
+		switch {
+		case c.ExceedsRequest && memReq.IsZero():
+			c.Reason = "uses memory without a request"
+		case c.ExceedsRequest:
+			c.Reason = "memory usage exceeds its request by " + formatBytesHuman(c.over)
+		case c.hasUsage:
+			c.Reason = "within its memory request"
+		default:
+			c.Reason = "no metrics; ranked by QoS class and priority"
+		}
+		candidates = append(candidates, c)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.ExceedsRequest != b.ExceedsRequest {
+			return a.ExceedsRequest
+		}
+		if podMetrics == nil {
+			if qa, qb := qosRank[v1.PodQOSClass(a.QOSClass)], qosRank[v1.PodQOSClass(b.QOSClass)]; qa != qb {
+				return qa < qb
+			}
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		if a.over != b.over {
+			return a.over > b.over
+		}
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	var resources []nodeResourceLine
+	for _, r := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourceEphemeralStorage, v1.ResourcePods} {
+		alloc, ok := node.Status.Allocatable[r]
+		if !ok {
+			continue
+		}
+		line := nodeResourceLine{Resource: string(r), Allocatable: alloc.String()}
+		value := func(q resource.Quantity) int64 {
+			if r == v1.ResourceCPU {
+				return q.MilliValue()
+			}
+			return q.Value()
+		}
+		if r == v1.ResourcePods {
+			line.Requested = fmt.Sprintf("%d", running)
+			line.RequestedPct = pct(int64(running), alloc.Value())
+			resources = append(resources, line)
+			continue
+		}
+		req := requested[r]
+		line.Requested = req.String()
+		line.RequestedPct = pct(value(req), value(alloc))
+		if lim, ok := limits[r]; ok {
+			line.Limits = lim.String()
+			line.LimitsPct = pct(value(lim), value(alloc))
+			line.Overcommitted = lim.Cmp(alloc) > 0
+		}
+		var usage *resource.Quantity
+		switch r {
+		case v1.ResourceCPU:
+			usage = nodeCPU
+		case v1.ResourceMemory:
+			usage = nodeMem
+		}
+		if usage != nil {
+			if r == v1.ResourceMemory {
+				line.Usage = formatBytesHuman(usage.Value())
+			} else {
+				line.Usage = fmt.Sprintf("%dm", usage.MilliValue())
+			}
+			line.UsagePct = pct(value(*usage), value(alloc))
+		}
+		resources = append(resources, line)
+	}
+
+	out := map[string]any{
+		"node":                nodeName,
+		"unschedulable":       node.Spec.Unschedulable,
+		"under_pressure":      underPressure,
+		"conditions":          conditions,
+		"resources":           resources,
+		"eviction_candidates": candidates,
+	}
+	if len(notes) > 0 {
+		out["notes"] = notes
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
@@ -3,7 +3,11 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -27,14 +31,7 @@ func K8sCordon(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any)
 		return textErrorResult(err.Error()), nil, nil
 	}
 
-	patch := map[string]any{
-		"spec": map[string]any{
-			"unschedulable": true,
-		},
-	}
-	data, _ := json.Marshal(patch)
-
-	if _, err := cs.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, data, metav1.PatchOptions{}); err != nil {
+	if err := setNodeUnschedulable(ctx, cs, nodeName, true); err != nil {
 		return textErrorResult(fmt.Sprintf("Error cordoning node %s: %v", nodeName, err)), nil, nil
 	}
 
@@ -53,18 +50,173 @@ func K8sUncordon(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 		return textErrorResult(err.Error()), nil, nil
 	}
 
+	if err := setNodeUnschedulable(ctx, cs, nodeName, false); err != nil {
+		return textErrorResult(fmt.Sprintf("Error uncordoning node %s: %v", nodeName, err)), nil, nil
+	}
+
+	return textOKResult(fmt.Sprintf("Node %s uncordoned successfully", nodeName)), nil, nil
+}
+
+// K8sCordonSelector cordons every node matching label_selector, e.g. a whole
+// node pool before maintenance. Patches run with bounded concurrency; a failing
+// node is reported in its row and does not stop the others.
+//
+// Args: label_selector (required)
+func K8sCordonSelector(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	return cordonBySelector(ctx, args, true)
+}
+
+// K8sUncordonSelector is the inverse of K8sCordonSelector.
+//
+// Args: label_selector (required)
+func K8sUncordonSelector(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	return cordonBySelector(ctx, args, false)
+}
+
+type cordonResult struct {
+	Node          string `json:"node"`
+	Unschedulable bool   `json:"unschedulable"`
+	Changed       bool   `json:"changed"`
+	Error         string `json:"error,omitempty"`
+}
+
+func cordonBySelector(ctx context.Context, args map[string]any, unschedulable bool) (*mcp.CallToolResult, any, error) {
+	selector := getStringArg(args, "label_selector", "selector")
+	if strings.TrimSpace(selector) == "" {
+		return textErrorResult("label_selector is required"), nil, nil
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	nodes, err := cs.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	if len(nodes.Items) == 0 {
+		return textErrorResult(fmt.Sprintf("Error: no nodes match label_selector %q", selector)), nil, nil
+	}
+
+	results := make([]cordonResult, len(nodes.Items))
+	var wg sync.WaitGroup
+	tokens := make(chan struct{}, getAllConcurrency)
+	for i := range nodes.Items {
+		wg.Add(1)
+		go func(node *v1.Node, r *cordonResult) {
+			defer wg.Done()
+			tokens <- struct{}{}
+			defer func() { <-tokens }()
+
+			r.Node = node.Name
+			r.Unschedulable = node.Spec.Unschedulable
+			if node.Spec.Unschedulable == unschedulable {
+				return
+			}
+			if err := setNodeUnschedulable(ctx, cs, node.Name, unschedulable); err != nil {
+				r.Error = formatK8sErr(err)
+				return
+			}
+			r.Unschedulable = unschedulable
+			r.Changed = true
+		}(&nodes.Items[i], &results[i])
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Node < results[j].Node })
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+	out := map[string]any{
+		"label_selector": selector,
+		"matched":        len(results),
+		"failed":         failed,
+		"nodes":          results,
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// setNodeUnschedulable patches spec.unschedulable on the node.
+func setNodeUnschedulable(ctx context.Context, cs *kubernetes.Clientset, nodeName string, unschedulable bool) error {
 	patch := map[string]any{
 		"spec": map[string]any{
-			"unschedulable": false,
+			"unschedulable": unschedulable,
 		},
 	}
 	data, _ := json.Marshal(patch)
 
-	if _, err := cs.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, data, metav1.PatchOptions{}); err != nil {
-		return textErrorResult(fmt.Sprintf("Error uncordoning node %s: %v", nodeName, err)), nil, nil
+	_, err := cs.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	return err
+}
+
+type nodePodRow struct {
+	Name       string   `json:"name"`
+	Namespace  string   `json:"namespace"`
+	Phase      string   `json:"phase"`
+	Owner      string   `json:"owner,omitempty"`
+	Containers []string `json:"containers"`
+}
+
+// K8sNodePods lists the pods scheduled on a node (spec.nodeName field selector).
+//
+// Args:
+// - node_name (string) required
+// - include_completed (bool) default false: also list Succeeded/Failed pods
+func K8sNodePods(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	nodeName, _ := args["node_name"].(string)
+	if nodeName == "" {
+		return textErrorResult("node_name is required"), nil, nil
 	}
+	includeCompleted := boolFromArgs(args, "include_completed", false)
 
-	return textOKResult(fmt.Sprintf("Node %s uncordoned successfully", nodeName)), nil, nil
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	pods, err := cs.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + nodeName,
+	})
+	if err != nil {
+		return textErrorResult(fmt.Sprintf("Error listing pods on node %s: %v", nodeName, err)), nil, nil
+	}
+
+	rows := make([]nodePodRow, 0, len(pods.Items))
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if !includeCompleted && isCompletedPod(p) {
+			continue
+		}
+		row := nodePodRow{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Phase:     string(p.Status.Phase),
+		}
+		if ref := metav1.GetControllerOf(p); ref != nil {
+			row.Owner = ref.Kind + "/" + ref.Name
+		}
+		for _, c := range p.Spec.Containers {
+			row.Containers = append(row.Containers, c.Name)
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Namespace != rows[j].Namespace {
+			return rows[i].Namespace < rows[j].Namespace
+		}
+		return rows[i].Name < rows[j].Name
+	})
+
+	b, _ := json.MarshalIndent(map[string]any{
+		"node":  nodeName,
+		"count": len(rows),
+		"pods":  rows,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
 }
 
 // K8sDrain is a drain implementation closer to `kubectl drain`:
@@ -85,6 +237,9 @@ func K8sUncordon(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 // - timeout_seconds (int) default 600
 // - retry_backoff_ms (int) default 1000
 // - max_backoff_ms (int) default 10000
+// - wait_empty (bool) default false: after evicting, poll until no pods other than
+// DaemonSet and mirror pods remain on the node (within the same timeout) and report
+// status "drained", or "drain_incomplete" with the pods still there
 func K8sDrain(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	nodeName, _ := args["node_name"].(string)
 	if nodeName == "" {
@@ -94,6 +249,7 @@ func K8sDrain(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any)
 	ignoreDaemonsets := boolFromArgs(args, "ignore_daemonsets", false)
 	deleteLocalData := boolFromArgs(args, "delete_local_data", false)
 	force := boolFromArgs(args, "force", false)
+	waitEmpty := boolFromArgs(args, "wait_empty", false)
 
 	timeoutSeconds := intFromArgsDefault(args, "timeout_seconds", 600)
 	retryBackoffMS := intFromArgsDefault(args, "retry_backoff_ms", 1000)
@@ -232,6 +388,20 @@ func K8sDrain(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any)
 		"results":           results,
 	}
 
+	if waitEmpty {
+		remaining, err := waitNodeEmpty(drainCtx, cs, nodeName)
+		summary["wait_empty"] = true
+		if len(remaining) == 0 && err == nil {
+			summary["status"] = "drained"
+		} else {
+			summary["status"] = "drain_incomplete"
+			summary["remaining_pods"] = remaining
+			if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+				summary["wait_error"] = formatK8sErr(err)
+			}
+		}
+	}
+
 	data, _ := json.MarshalIndent(summary, "", "  ")
 	return textOKResult(string(data)), nil, nil
 }
@@ -329,6 +499,46 @@ func waitPodDeleted(ctx context.Context, cs *kubernetes.Clientset, namespace, na
 	}
 }
 
+// waitNodeEmpty polls the node's pods until only DaemonSet, mirror and
+// completed pods are left. On timeout it returns the "namespace/name" of the
+// pods still there together with the context error.
+func waitNodeEmpty(ctx context.Context, cs *kubernetes.Clientset, nodeName string) ([]string, error) {
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+
+	remaining := []string{}
+	var lastErr error
+	for {
+		pods, err := cs.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+			FieldSelector: "spec.nodeName=" + nodeName,
+		})
+		if err != nil {
+			// transient list errors are retried until the deadline
+			lastErr = err
+		} else {
+			remaining = remaining[:0]
+			for i := range pods.Items {
+				pod := &pods.Items[i]
+				if isCompletedPod(pod) || isMirrorPod(pod) || isOwnedBy(pod, "DaemonSet") {
+					continue
+				}
+				remaining = append(remaining, pod.Namespace+"/"+pod.Name)
+			}
+			if len(remaining) == 0 {
+				return nil, nil
+			}
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			if lastErr != nil && len(remaining) == 0 {
+				return remaining, lastErr
+			}
+			return remaining, ctx.Err()
+		}
+	}
+}
+
 // ---- helpers for drain ----
 
 func boolFromArgs(args map[string]any, key string, def bool) bool {
@@ -0,0 +1,214 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+type objectFieldDiff struct {
+	Path string `json:"path"`
+	Op   string `json:"op"` // "add" (not in live), "change", or "remove" (list item only in live)
+	Live any    `json:"live,omitempty"`
+	Want any    `json:"want,omitempty"`
+}
+
+// K8sObjectDiff compares one manifest with the live object, read-only.
+//
+// Only fields the manifest sets are compared, so server defaults don't show up
+// as noise; status and server-populated metadata (resourceVersion, uid,
+// managedFields, ...) are ignored. List items that carry a "name" (containers,
+// env, ports, volumes) are matched by name, and named items present only in the
+// live list are reported as removals. A missing object is a full addition.
+//
+// Args: yaml_content (single object); namespace overrides the manifest's
+func K8sObjectDiff(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	yamlContent := getStringArg(args, "yaml_content", "yaml")
+	namespace := getStringArg(args, "namespace")
+
+	if strings.TrimSpace(yamlContent) == "" {
+		return textErrorResult("yaml_content is required"), nil, nil
+	}
+
+	dec := k8syaml.NewYAMLOrJSONDecoder(strings.NewReader(yamlContent), 4096)
+	var desired map[string]any
+	for {
+		var raw map[string]any
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return textErrorResult(fmt.Sprintf("Error: decode error: %v", err)), nil, nil
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		if desired != nil {
+			return textErrorResult("Error: yaml_content must contain a single object"), nil, nil
+		}
+		desired = raw
+	}
+	if desired == nil {
+		return textErrorResult("Error: No valid YAML/JSON content provided"), nil, nil
+	}
+
+	u := &unstructured.Unstructured{Object: desired}
+	if u.GetAPIVersion() == "" || u.GetKind() == "" {
+		return textErrorResult("Error: object missing apiVersion/kind"), nil, nil
+	}
+	if u.GetName() == "" {
+		return textErrorResult("Error: object missing metadata.name"), nil, nil
+	}
+
+	dyn, err := GetDynamicClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	mapper, err := GetRESTMapper()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	gvk := schema.FromAPIVersionAndKind(u.GetAPIVersion(), u.GetKind())
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return textErrorResult(fmt.Sprintf("Error: cannot map GVK %s: %v", gvk.String(), err)), nil, nil
+	}
+
+	var live *unstructured.Unstructured
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if namespace != "" {
+			u.SetNamespace(namespace)
+		}
+		if u.GetNamespace() == "" {
+			u.SetNamespace(defaultNamespace(ctx))
+		}
+		live, err = dyn.Resource(mapping.Resource).Namespace(u.GetNamespace()).Get(ctx, u.GetName(), metav1.GetOptions{})
+	} else {
+		u.SetNamespace("")
+		live, err = dyn.Resource(mapping.Resource).Get(ctx, u.GetName(), metav1.GetOptions{})
+	}
+
+	out := map[string]any{
+		"kind":      u.GetKind(),
+		"name":      u.GetName(),
+		"namespace": u.GetNamespace(),
+	}
+	switch {
+	case apierrors.IsNotFound(err):
+		stripServerFields(u.Object)
+		out["exists"] = false
+		out["diff"] = []objectFieldDiff{{Path: ".", Op: "add", Want: u.Object}}
+	case err != nil:
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	default:
+		stripServerFields(u.Object)
+		stripServerFields(live.Object)
+
+		want, wantItems := map[string]any{}, map[string]bool{}
+		have, haveItems := map[string]any{}, map[string]bool{}
+		flattenObject(u.Object, "", want, wantItems)
+		flattenObject(live.Object, "", have, haveItems)
+
+		diffs := []objectFieldDiff{}
+		for p, w := range want {
+			h, ok := have[p]
+			switch {
+			case !ok:
+				diffs = append(diffs, objectFieldDiff{Path: p, Op: "add", Want: w})
+			case fmtAny(h) != fmtAny(w):
+				diffs = append(diffs, objectFieldDiff{Path: p, Op: "change", Live: h, Want: w})
+			}
+		}
+		// Named list items only in live: report them if the manifest manages the list.
+		for p := range haveItems {
+			if wantItems[p] {
+				continue
+			}
+			if parent := p[:strings.LastIndex(p, "[")]; wantItems[parent+"[]"] {
+				diffs = append(diffs, objectFieldDiff{Path: p, Op: "remove"})
+			}
+		}
+		sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+
+		out["exists"] = true
+		out["resource_version"] = live.GetResourceVersion()
+		out["diff"] = diffs
+		out["in_sync"] = len(diffs) == 0
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// stripServerFields drops fields the server owns so they never show as drift.
+func stripServerFields(obj map[string]any) {
+	delete(obj, "status")
+	if md, ok := obj["metadata"].(map[string]any); ok {
+		for _, k := range []string{"resourceVersion", "uid", "generation", "creationTimestamp", "managedFields", "selfLink", "deletionTimestamp", "deletionGracePeriodSeconds"} {
+			delete(md, k)
+		}
+		if ann, ok := md["annotations"].(map[string]any); ok {
+			delete(ann, "kubectl.kubernetes.io/last-applied-configuration")
+			delete(ann, "deployment.kubernetes.io/revision")
+			if len(ann) == 0 {
+				delete(md, "annotations")
+			}
+		}
+	}
+}
+
+// flattenObject records leaf values by path (".spec.replicas",
+// ".spec.template.spec.containers[app].image"). Lists whose items all have a
+// "name" are keyed by name and recorded in items (the list itself as "path[]");
+// other lists are compared as a whole.
+func flattenObject(v any, path string, leaves map[string]any, items map[string]bool) {
+	switch t := v.(type) {
+	case map[string]any:
+		if len(t) == 0 {
+			leaves[path] = t
+			return
+		}
+		for k, child := range t {
+			flattenObject(child, path+"."+k, leaves, items)
+		}
+	case []any:
+		named := len(t) > 0
+		for _, item := range t {
+			m, ok := item.(map[string]any)
+			if !ok {
+				named = false
+				break
+			}
+			if _, ok := m["name"].(string); !ok {
+				named = false
+				break
+			}
+		}
+		if !named {
+			leaves[path] = t
+			return
+		}
+		items[path+"[]"] = true
+		for _, item := range t {
+			m := item.(map[string]any)
+			p := fmt.Sprintf("%s[%s]", path, m["name"])
+			items[p] = true
+			flattenObject(m, p, leaves, items)
+		}
+	default:
+		leaves[path] = t
+	}
+}
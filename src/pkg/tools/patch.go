@@ -0,0 +1,250 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+)
+
+// K8sPatch ports `kubectl patch` through the dynamic client.
+//
+// Args:
+// - resource_type, name required; namespace defaults to defaultNamespace()
+// - patch (object, array or JSON string) required
+// - patch_type "strategic" (default, built-in types only), "merge" or "json"
+// - resource_version: optional precondition; the patch fails with a Conflict if the live object differs
+// - confirm (bool): required for protected kinds (--protected-kinds)
+func K8sPatch(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType, _ := args["resource_type"].(string)
+	name := getStringArg(args, "name", "resource_name")
+	namespace, _ := args["namespace"].(string)
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	pt, err := patchTypeFromArg(getStringArg(args, "patch_type"), types.StrategicMergePatchType)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+	patch, err := patchBytesFromArg(args["patch"])
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+	patch, err = withResourceVersion(patch, pt, getStringArg(args, "resource_version"))
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
+	}
+	if err := checkProtected(gvr, name, args, "patching"); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	var out *unstructured.Unstructured
+	if namespaced {
+		out, err = dyn.Resource(gvr).Namespace(namespace).Patch(ctx, name, pt, patch, metav1.PatchOptions{})
+	} else {
+		out, err = dyn.Resource(gvr).Patch(ctx, name, pt, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		if apierrors.IsUnsupportedMediaType(err) && pt == types.StrategicMergePatchType {
+			return textErrorResult(formatK8sErr(err) + "\nStrategic merge patch is not supported for this resource; use patch_type=merge."), nil, nil
+		}
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	b, _ := json.MarshalIndent(out.Object, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// K8sPatchStatus patches the status subresource of a resource (typically a CRD
+// instance) through the dynamic client.
+//
+// A merge patch without a top-level "status" key is treated as the status body itself.
+//
+// Args:
+// - resource_type, name required; namespace defaults to defaultNamespace()
+// - patch (object, array or JSON string) required
+// - patch_type "merge" (default) or "json"
+// - resource_version: optional precondition, as for k8s_patch
+// - confirm (bool): required for protected kinds, as for k8s_patch
+func K8sPatchStatus(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType, _ := args["resource_type"].(string)
+	name, _ := args["name"].(string)
+	namespace, _ := args["namespace"].(string)
+	patchType := strings.ToLower(getStringArg(args, "patch_type"))
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	if patchType == "strategic" {
+		return textErrorResult("Error: unsupported patch_type 'strategic' for status (expected merge or json)"), nil, nil
+	}
+	pt, err := patchTypeFromArg(patchType, types.MergePatchType)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	patch, err := patchBytesFromArg(args["patch"])
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+	if pt == types.MergePatchType {
+		var m map[string]any
+		if err := json.Unmarshal(patch, &m); err != nil {
+			return textErrorResult("Error: merge patch must be a JSON object"), nil, nil
+		}
+		if _, ok := m["status"]; !ok {
+			patch, _ = json.Marshal(map[string]any{"status": m})
+		}
+	}
+	patch, err = withResourceVersion(patch, pt, getStringArg(args, "resource_version"))
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
+	}
+	if err := checkProtected(gvr, name, args, "patching"); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	if !hasSubresource(disc, gvr, "status") {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' does not expose a status subresource", gvr.Resource)), nil, nil
+	}
+
+	var out *unstructured.Unstructured
+	if namespaced {
+		out, err = dyn.Resource(gvr).Namespace(namespace).Patch(ctx, name, pt, patch, metav1.PatchOptions{}, "status")
+	} else {
+		out, err = dyn.Resource(gvr).Patch(ctx, name, pt, patch, metav1.PatchOptions{}, "status")
+	}
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	b, _ := json.MarshalIndent(out.Object, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+func patchTypeFromArg(s string, def types.PatchType) (types.PatchType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return def, nil
+	case "strategic":
+		return types.StrategicMergePatchType, nil
+	case "merge":
+		return types.MergePatchType, nil
+	case "json":
+		return types.JSONPatchType, nil
+	}
+	return "", fmt.Errorf("unsupported patch_type '%s' (expected strategic, merge or json)", s)
+}
+
+// withResourceVersion turns rv into a precondition: the apiserver rejects a patch
+// whose resulting metadata.resourceVersion differs from the live one with 409 Conflict.
+// Merge-style patches carry it in metadata; JSON patches get an extra replace op.
+func withResourceVersion(patch []byte, pt types.PatchType, rv string) ([]byte, error) {
+	if rv == "" {
+		return patch, nil
+	}
+	if pt == types.JSONPatchType {
+		var ops []any
+		if err := json.Unmarshal(patch, &ops); err != nil {
+			return nil, fmt.Errorf("json patch must be a JSON array")
+		}
+		ops = append(ops, map[string]any{"op": "replace", "path": "/metadata/resourceVersion", "value": rv})
+		return json.Marshal(ops)
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(patch, &m); err != nil {
+		return nil, fmt.Errorf("merge patch must be a JSON object")
+	}
+	meta, _ := m["metadata"].(map[string]any)
+	if meta == nil {
+		meta = map[string]any{}
+	}
+	meta["resourceVersion"] = rv
+	m["metadata"] = meta
+	return json.Marshal(m)
+}
+
+// hasSubresource reports whether discovery lists "<resource>/<sub>" for gvr's group/version.
+func hasSubresource(disc discovery.DiscoveryInterface, gvr schema.GroupVersionResource, sub string) bool {
+	rl, err := disc.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil || rl == nil {
+		return false
+	}
+	want := gvr.Resource + "/" + sub
+	for _, r := range rl.APIResources {
+		if r.Name == want {
+			return true
+		}
+	}
+	return false
+}
+
+// patchBytesFromArg accepts a patch as a JSON string or as already-decoded JSON.
+func patchBytesFromArg(v any) ([]byte, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, fmt.Errorf("patch is required")
+	case string:
+		s := strings.TrimSpace(t)
+		if s == "" {
+			return nil, fmt.Errorf("patch is required")
+		}
+		if !json.Valid([]byte(s)) {
+			return nil, fmt.Errorf("patch is not valid JSON")
+		}
+		return []byte(s), nil
+	default:
+		return json.Marshal(t)
+	}
+}
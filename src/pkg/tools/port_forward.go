@@ -56,7 +56,7 @@ func K8sPortForward(ctx context.Context, _ *mcp.CallToolRequest, args map[string
 	address := getStringArg(args, "address")
 
 	if strings.TrimSpace(namespace) == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 	if strings.TrimSpace(address) == "" {
 		address = "127.0.0.1"
@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// progressFlushInterval batches streamed output into notifications.
+const progressFlushInterval = 250 * time.Millisecond
+
+// progressStreamer forwards streamed tool output (follow logs) to the client as
+// notifications/progress messages while the call is still running; the full
+// output is still returned as the tool result. Chunks are batched and sent at
+// most every progressFlushInterval, with Progress counting bytes sent so far.
+type progressStreamer struct {
+	session *mcp.ServerSession
+	token   any
+
+	mu      sync.Mutex
+	pending strings.Builder
+	sent    int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newProgressStreamer returns nil unless streaming is enabled (HTTP
+// transports) and the client asked for progress with a progressToken.
+func newProgressStreamer(ctx context.Context, req *mcp.CallToolRequest) *progressStreamer {
+	if !streamProgress || req == nil || req.Session == nil || req.Params == nil {
+		return nil
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return nil
+	}
+	p := &progressStreamer{
+		session: req.Session,
+		token:   token,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go p.loop(ctx)
+	return p
+}
+
+// write queues a chunk; safe on a nil streamer.
+func (p *progressStreamer) write(s string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.pending.WriteString(s)
+	p.mu.Unlock()
+}
+
+// close flushes what is left and stops the flusher; safe on a nil streamer.
+func (p *progressStreamer) close() {
+	if p == nil {
+		return
+	}
+	close(p.stop)
+	<-p.done
+}
+
+func (p *progressStreamer) loop(ctx context.Context) {
+	defer close(p.done)
+	t := time.NewTicker(progressFlushInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			p.flush(ctx)
+		case <-p.stop:
+			p.flush(ctx)
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *progressStreamer) flush(ctx context.Context) {
+	p.mu.Lock()
+	chunk := p.pending.String()
+	p.pending.Reset()
+	p.sent += len(chunk)
+	sent := p.sent
+	p.mu.Unlock()
+	if chunk == "" {
+		return
+	}
+	// Best-effort: a client that stops listening still gets the final result.
+	_ = p.session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: p.token,
+		Progress:      float64(sent),
+		Message:       chunk,
+	})
+}
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+type pruneResult struct {
+	GVR       string `json:"gvr"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+// k8sApplyPrune mirrors `kubectl apply --prune -l <selector>`:
+// - server-side apply every document
+// - for each GVR (and namespace) seen in the manifest, list live objects matching selector
+// - delete the ones that were not part of this apply
+//
+// Pruning is skipped entirely when any document failed, so a bad manifest can't
+// cause live objects to be removed. Protected kinds (--protected-kinds) are never pruned.
+func k8sApplyPrune(ctx context.Context, yamlContent, namespace, selector string, confirm bool) (string, error) {
+	if deleteDisabled {
+		return "", fmt.Errorf("Error: Delete operations are not allowed. Cannot prune.")
+	}
+	if strings.TrimSpace(selector) == "" {
+		return "", fmt.Errorf("prune_selector is required when prune=true")
+	}
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid prune_selector %q: %w", selector, err)
+	}
+	if sel.Empty() {
+		return "", fmt.Errorf("prune_selector must not select everything")
+	}
+	if strings.TrimSpace(yamlContent) == "" {
+		return `{"error":"No valid YAML/JSON content provided"}`, nil
+	}
+
+	results, err := createOrApplyDocs(ctx, yamlContent, namespace, true, true, false, confirm)
+	if err != nil {
+		return "", err
+	}
+
+	dyn, err := GetDynamicClient()
+	if err != nil {
+		return "", err
+	}
+
+	created := []createResult{}
+	updated := []createResult{}
+	failed := []createResult{}
+
+	type scope struct {
+		gvr        schema.GroupVersionResource
+		namespaced bool
+		namespaces map[string]bool
+	}
+	scopes := map[string]*scope{}
+	keep := map[string]bool{}
+
+	for _, r := range results {
+		if r.Status == "error" {
+			failed = append(failed, r)
+			continue
+		}
+		if r.existed {
+			updated = append(updated, r)
+		} else {
+			created = append(created, r)
+		}
+
+		meta, _ := r.Result["metadata"].(map[string]any)
+		name := fmtAny(meta["name"])
+		ns := fmtAny(meta["namespace"])
+		keep[pruneKey(r.gvr, ns, name)] = true
+
+		s := scopes[r.gvr.String()]
+		if s == nil {
+			s = &scope{gvr: r.gvr, namespaced: r.namespaced, namespaces: map[string]bool{}}
+			scopes[r.gvr.String()] = s
+		}
+		if r.namespaced {
+			s.namespaces[ns] = true
+		}
+	}
+
+	out := map[string]any{
+		"created": created,
+		"updated": updated,
+	}
+
+	if len(failed) > 0 {
+		out["errors"] = failed
+		out["pruned"] = []pruneResult{}
+		out["prune_skipped"] = fmt.Sprintf("%d document(s) failed to apply; nothing was pruned", len(failed))
+		b, _ := json.MarshalIndent(out, "", "  ")
+		return string(b), nil
+	}
+
+	keys := make([]string, 0, len(scopes))
+	for k := range scopes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pruned := []pruneResult{}
+	for _, k := range keys {
+		s := scopes[k]
+		if !s.namespaced {
+			pruned = append(pruned, pruneScope(ctx, dyn.Resource(s.gvr), s.gvr, "", selector, keep)...)
+			continue
+		}
+		for ns := range s.namespaces {
+			pruned = append(pruned, pruneScope(ctx, dyn.Resource(s.gvr).Namespace(ns), s.gvr, ns, selector, keep)...)
+		}
+	}
+	out["pruned"] = pruned
+
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func pruneScope(ctx context.Context, ri dynamic.ResourceInterface, gvr schema.GroupVersionResource, ns, selector string, keep map[string]bool) []pruneResult {
+	list, err := ri.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return []pruneResult{{
+			GVR:       gvr.String(),
+			Namespace: ns,
+			Status:    "error",
+			Message:   "list: " + err.Error(),
+		}}
+	}
+
+	var out []pruneResult
+	policy := metav1.DeletePropagationBackground
+	for i := range list.Items {
+		item := &list.Items[i]
+		if keep[pruneKey(gvr, item.GetNamespace(), item.GetName())] {
+			continue
+		}
+		if item.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		res := pruneResult{
+			GVR:       gvr.String(),
+			Namespace: item.GetNamespace(),
+			Name:      item.GetName(),
+			Status:    "pruned",
+		}
+		if isProtectedKind(item.GetKind()) {
+			res.Status = "skipped"
+			res.Message = "protected kind; delete it explicitly with confirm=true"
+			out = append(out, res)
+			continue
+		}
+		if err := ri.Delete(ctx, item.GetName(), metav1.DeleteOptions{PropagationPolicy: &policy}); err != nil {
+			res.Status = "error"
+			res.Message = err.Error()
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+func pruneKey(gvr schema.GroupVersionResource, ns, name string) string {
+	return gvr.String() + "|" + ns + "|" + name
+}
@@ -0,0 +1,47 @@
+package tools
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// qosRank orders QoS classes the way the kubelet evicts them: BestEffort first.
+var qosRank = map[v1.PodQOSClass]int{
+	v1.PodQOSBestEffort: 0,
+	v1.PodQOSBurstable:  1,
+	v1.PodQOSGuaranteed: 2,
+}
+
+// podQOSClass computes the pod's QoS class from its containers' requests and
+// limits, following the kubelet's rules (only cpu and memory count):
+// - BestEffort: no container sets any cpu/memory request or limit
+// - Guaranteed: every container sets cpu and memory limits, and requests (when
+// set) equal them
+// - Burstable: anything else
+func podQOSClass(pod *v1.Pod) v1.PodQOSClass {
+	hasResources := false
+	guaranteed := true
+	all := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, c := range all {
+		for _, r := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			req, hasReq := c.Resources.Requests[r]
+			lim, hasLim := c.Resources.Limits[r]
+			if (hasReq && !req.IsZero()) || (hasLim && !lim.IsZero()) {
+				hasResources = true
+			}
+			if !hasLim || lim.IsZero() {
+				guaranteed = false
+				continue
+			}
+			if hasReq && req.Cmp(lim) != 0 {
+				guaranteed = false
+			}
+		}
+	}
+	switch {
+	case !hasResources:
+		return v1.PodQOSBestEffort
+	case guaranteed:
+		return v1.PodQOSGuaranteed
+	}
+	return v1.PodQOSBurstable
+}
@@ -0,0 +1,281 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+)
+
+// quotaLine is one quota resource the manifest would consume.
+type quotaLine struct {
+	Namespace string `json:"namespace"`
+	Quota     string `json:"quota"`
+	Resource  string `json:"resource"`
+	Used      string `json:"used"`
+	Requested string `json:"requested"`
+	Projected string `json:"projected"`
+	Hard      string `json:"hard"`
+	Exceeds   bool   `json:"exceeds"`
+}
+
+type quotaPreflightReport struct {
+	Exceeds bool        `json:"exceeds"`
+	Lines   []quotaLine `json:"usage"`
+	Notes   []string    `json:"notes,omitempty"`
+}
+
+// quotaPreflight estimates what the manifest adds to each namespace's
+// ResourceQuotas and projects used+requested against hard. Workloads count
+// their pods at full scale (replicas, or parallelism for Jobs/CronJobs); pod
+// requests/limits are the sum of the containers or the largest init container,
+// whichever is larger. DaemonSet pods depend on the node count and are left out.
+// Only quota resources the manifest touches are reported.
+func quotaPreflight(ctx context.Context, yamlContent, namespace string) (*quotaPreflightReport, error) {
+	cs, err := getClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := GetRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &quotaPreflightReport{Lines: []quotaLine{}}
+	demand := map[string]map[v1.ResourceName]resource.Quantity{} // namespace -> quota resource -> amount
+	add := func(ns string, name v1.ResourceName, q resource.Quantity) {
+		if demand[ns] == nil {
+			demand[ns] = map[v1.ResourceName]resource.Quantity{}
+		}
+		cur := demand[ns][name]
+		cur.Add(q)
+		demand[ns][name] = cur
+	}
+
+	dec := k8syaml.NewYAMLOrJSONDecoder(strings.NewReader(yamlContent), 4096)
+	for {
+		var raw map[string]any
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("decode error: %v", err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		u := &unstructured.Unstructured{Object: raw}
+		gvk := schema.FromAPIVersionAndKind(u.GetAPIVersion(), u.GetKind())
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil || mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+			continue
+		}
+		ns := namespace
+		if ns == "" {
+			ns = u.GetNamespace()
+		}
+		if ns == "" {
+			ns = defaultNamespace(ctx)
+		}
+
+		one := resource.MustParse("1")
+		countName := "count/" + mapping.Resource.Resource
+		if mapping.Resource.Group != "" {
+			countName += "." + mapping.Resource.Group
+		}
+		add(ns, v1.ResourceName(countName), one)
+		switch mapping.Resource.GroupResource() {
+		case schema.GroupResource{Resource: "services"}:
+			add(ns, v1.ResourceServices, one)
+			if t, _, _ := unstructured.NestedString(raw, "spec", "type"); t == "LoadBalancer" {
+				add(ns, v1.ResourceServicesLoadBalancers, one)
+			} else if t == "NodePort" {
+				add(ns, v1.ResourceServicesNodePorts, one)
+			}
+		case schema.GroupResource{Resource: "configmaps"}:
+			add(ns, v1.ResourceConfigMaps, one)
+		case schema.GroupResource{Resource: "secrets"}:
+			add(ns, v1.ResourceSecrets, one)
+		case schema.GroupResource{Resource: "persistentvolumeclaims"}:
+			var pvc v1.PersistentVolumeClaim
+			if runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &pvc) == nil {
+				addClaim(ns, &pvc, 1, add)
+			}
+		}
+
+		path := podSpecPathForKind(u.GetKind())
+		if g := mapping.Resource.Group; path == nil || (g != "" && g != "apps" && g != "batch") {
+			continue
+		}
+		if strings.EqualFold(u.GetKind(), "daemonset") {
+			report.Notes = append(report.Notes, fmt.Sprintf("DaemonSet %s/%s: pods per node are not counted", ns, u.GetName()))
+			continue
+		}
+		specMap, found, _ := unstructured.NestedMap(raw, path...)
+		if !found {
+			continue
+		}
+		var spec v1.PodSpec
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(specMap, &spec); err != nil {
+			report.Notes = append(report.Notes, fmt.Sprintf("%s %s/%s: cannot read pod spec: %v", u.GetKind(), ns, u.GetName(), err))
+			continue
+		}
+
+		pods := int64(1)
+		switch strings.ToLower(u.GetKind()) {
+		case "deployment", "statefulset", "replicaset":
+			if r, found, _ := unstructured.NestedInt64(raw, "spec", "replicas"); found {
+				pods = r
+			}
+		case "job":
+			if p, found, _ := unstructured.NestedInt64(raw, "spec", "parallelism"); found {
+				pods = p
+			}
+		case "cronjob":
+			if p, found, _ := unstructured.NestedInt64(raw, "spec", "jobTemplate", "spec", "parallelism"); found {
+				pods = p
+			}
+		}
+		if pods <= 0 {
+			continue
+		}
+		add(ns, v1.ResourcePods, *resource.NewQuantity(pods, resource.DecimalSI))
+		requests, limits := podRequestsAndLimits(&spec)
+		for r, q := range requests {
+			q = scaleQuantity(q, pods)
+			add(ns, v1.ResourceName("requests."+string(r)), q)
+			if r == v1.ResourceCPU || r == v1.ResourceMemory || r == v1.ResourceEphemeralStorage {
+				add(ns, r, q)
+			}
+		}
+		for r, q := range limits {
+			add(ns, v1.ResourceName("limits."+string(r)), scaleQuantity(q, pods))
+		}
+
+		if strings.EqualFold(u.GetKind(), "statefulset") {
+			templates, _, _ := unstructured.NestedSlice(raw, "spec", "volumeClaimTemplates")
+			for _, t := range templates {
+				m, ok := t.(map[string]any)
+				if !ok {
+					continue
+				}
+				var pvc v1.PersistentVolumeClaim
+				if runtime.DefaultUnstructuredConverter.FromUnstructured(m, &pvc) == nil {
+					addClaim(ns, &pvc, pods, add)
+				}
+			}
+		}
+	}
+
+	namespaces := make([]string, 0, len(demand))
+	for ns := range demand {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	for _, ns := range namespaces {
+		lines, err := projectQuota(ctx, cs, ns, demand[ns])
+		if err != nil {
+			report.Notes = append(report.Notes, fmt.Sprintf("namespace %s: cannot list quotas: %s", ns, formatK8sErr(err)))
+			continue
+		}
+		for _, l := range lines {
+			report.Exceeds = report.Exceeds || l.Exceeds
+		}
+		report.Lines = append(report.Lines, lines...)
+	}
+	return report, nil
+}
+
+// projectQuota compares demand with every ResourceQuota in ns.
+func projectQuota(ctx context.Context, cs *kubernetes.Clientset, ns string, demand map[v1.ResourceName]resource.Quantity) ([]quotaLine, error) {
+	quotas, err := cs.CoreV1().ResourceQuotas(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var lines []quotaLine
+	for _, q := range quotas.Items {
+		for name, hard := range q.Status.Hard {
+			want, ok := demand[name]
+			if !ok {
+				continue
+			}
+			used := q.Status.Used[name]
+			projected := used.DeepCopy()
+			projected.Add(want)
+			lines = append(lines, quotaLine{
+				Namespace: ns,
+				Quota:     q.Name,
+				Resource:  string(name),
+				Used:      used.String(),
+				Requested: want.String(),
+				Projected: projected.String(),
+				Hard:      hard.String(),
+				Exceeds:   projected.Cmp(hard) > 0,
+			})
+		}
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].Quota != lines[j].Quota {
+			return lines[i].Quota < lines[j].Quota
+		}
+		return lines[i].Resource < lines[j].Resource
+	})
+	return lines, nil
+}
+
+// podRequestsAndLimits is the pod's effective requests and limits: per
+// resource the larger of the containers' sum and the biggest init container.
+func podRequestsAndLimits(spec *v1.PodSpec) (v1.ResourceList, v1.ResourceList) {
+	effective := func(get func(c v1.Container) v1.ResourceList) v1.ResourceList {
+		out := v1.ResourceList{}
+		for _, c := range spec.Containers {
+			for r, q := range get(c) {
+				cur := out[r]
+				cur.Add(q)
+				out[r] = cur
+			}
+		}
+		for _, c := range spec.InitContainers {
+			for r, q := range get(c) {
+				if cur, ok := out[r]; !ok || q.Cmp(cur) > 0 {
+					out[r] = q.DeepCopy()
+				}
+			}
+		}
+		return out
+	}
+	requests := effective(func(c v1.Container) v1.ResourceList { return c.Resources.Requests })
+	limits := effective(func(c v1.Container) v1.ResourceList { return c.Resources.Limits })
+	return requests, limits
+}
+
+// addClaim records count pvcs of this shape, including the per-storage-class quota names.
+func addClaim(ns string, pvc *v1.PersistentVolumeClaim, count int64, add func(string, v1.ResourceName, resource.Quantity)) {
+	add(ns, v1.ResourcePersistentVolumeClaims, *resource.NewQuantity(count, resource.DecimalSI))
+	storage, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+	if !ok {
+		return
+	}
+	total := scaleQuantity(storage, count)
+	add(ns, v1.ResourceRequestsStorage, total)
+	if sc := pvc.Spec.StorageClassName; sc != nil && *sc != "" {
+		add(ns, v1.ResourceName(*sc+".storageclass.storage.k8s.io/requests.storage"), total)
+		add(ns, v1.ResourceName(*sc+".storageclass.storage.k8s.io/persistentvolumeclaims"), *resource.NewQuantity(count, resource.DecimalSI))
+	}
+}
+
+func scaleQuantity(q resource.Quantity, n int64) resource.Quantity {
+	return *resource.NewMilliQuantity(q.MilliValue()*n, q.Format)
+}
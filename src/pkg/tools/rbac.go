@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type rbacRule struct {
+	Verbs           []string `json:"verbs"`
+	APIGroups       []string `json:"api_groups,omitempty"`
+	Resources       []string `json:"resources,omitempty"`
+	ResourceNames   []string `json:"resource_names,omitempty"`
+	NonResourceURLs []string `json:"non_resource_urls,omitempty"`
+}
+
+type rbacGrant struct {
+	Binding string     `json:"binding"`
+	Scope   string     `json:"scope"` // "cluster" or the RoleBinding's namespace
+	Role    string     `json:"role"`
+	Rules   []rbacRule `json:"rules"`
+	Error   string     `json:"error,omitempty"`
+}
+
+// K8sRbacFor is the admin-side complement of k8s_auth_can_i: it lists the
+// RoleBindings and ClusterRoleBindings that reference a subject and resolves the
+// rules of the referenced Roles/ClusterRoles.
+//
+// Only direct bindings are reported; permissions reached through groups the
+// subject belongs to (e.g. system:authenticated) are not expanded.
+//
+// Args:
+// - subject_kind: User, Group or ServiceAccount (required)
+// - subject_name (required)
+// - namespace: the ServiceAccount's namespace, defaults to defaultNamespace()
+func K8sRbacFor(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	kind := getStringArg(args, "subject_kind")
+	name := getStringArg(args, "subject_name")
+	namespace := getStringArg(args, "namespace")
+
+	switch strings.ToLower(kind) {
+	case "user":
+		kind = rbacv1.UserKind
+	case "group":
+		kind = rbacv1.GroupKind
+	case "serviceaccount", "sa":
+		kind = rbacv1.ServiceAccountKind
+		if namespace == "" {
+			namespace = defaultNamespace(ctx)
+		}
+	case "":
+		return textErrorResult("subject_kind is required"), nil, nil
+	default:
+		return textErrorResult(fmt.Sprintf("Error: unsupported subject_kind '%s' (expected User, Group or ServiceAccount)", kind)), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("subject_name is required"), nil, nil
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	matches := func(subjects []rbacv1.Subject) bool {
+		for _, s := range subjects {
+			if s.Kind != kind || s.Name != name {
+				continue
+			}
+			if kind == rbacv1.ServiceAccountKind && s.Namespace != namespace {
+				continue
+			}
+			return true
+		}
+		return false
+	}
+
+	grants := []rbacGrant{}
+
+	crbs, err := cs.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	for _, b := range crbs.Items {
+		if !matches(b.Subjects) {
+			continue
+		}
+		grants = append(grants, resolveRoleRef(ctx, cs, b.Name, "cluster", "", b.RoleRef))
+	}
+
+	rbs, err := cs.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	for _, b := range rbs.Items {
+		if !matches(b.Subjects) {
+			continue
+		}
+		grants = append(grants, resolveRoleRef(ctx, cs, b.Name, b.Namespace, b.Namespace, b.RoleRef))
+	}
+
+	sort.SliceStable(grants, func(i, j int) bool {
+		if grants[i].Scope != grants[j].Scope {
+			return grants[i].Scope == "cluster" || (grants[j].Scope != "cluster" && grants[i].Scope < grants[j].Scope)
+		}
+		return grants[i].Binding < grants[j].Binding
+	})
+
+	subject := map[string]any{"kind": kind, "name": name}
+	if kind == rbacv1.ServiceAccountKind {
+		subject["namespace"] = namespace
+	}
+	b, _ := json.MarshalIndent(map[string]any{
+		"subject":  subject,
+		"bindings": grants,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// resolveRoleRef loads the Role (in roleNS) or ClusterRole a binding points at.
+func resolveRoleRef(ctx context.Context, cs *kubernetes.Clientset, binding, scope, roleNS string, ref rbacv1.RoleRef) rbacGrant {
+	g := rbacGrant{Binding: binding, Scope: scope, Role: ref.Kind + "/" + ref.Name, Rules: []rbacRule{}}
+
+	var rules []rbacv1.PolicyRule
+	switch ref.Kind {
+	case "ClusterRole":
+		cr, err := cs.RbacV1().ClusterRoles().Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			g.Error = roleRefError(err)
+			return g
+		}
+		rules = cr.Rules
+	case "Role":
+		r, err := cs.RbacV1().Roles(roleNS).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			g.Error = roleRefError(err)
+			return g
+		}
+		rules = r.Rules
+	default:
+		g.Error = "unsupported roleRef kind " + ref.Kind
+		return g
+	}
+
+	for _, r := range rules {
+		g.Rules = append(g.Rules, rbacRule{
+			Verbs:           r.Verbs,
+			APIGroups:       r.APIGroups,
+			Resources:       r.Resources,
+			ResourceNames:   r.ResourceNames,
+			NonResourceURLs: r.NonResourceURLs,
+		})
+	}
+	return g
+}
+
+func roleRefError(err error) string {
+	if apierrors.IsNotFound(err) {
+		return "referenced role does not exist (the binding grants nothing)"
+	}
+	return formatK8sErr(err)
+}
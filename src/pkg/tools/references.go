@@ -0,0 +1,234 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type referenceRow struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Container string `json:"container,omitempty"`
+	Via       string `json:"via"`
+	Optional  bool   `json:"optional,omitempty"`
+}
+
+// K8sReferences lists what in a namespace uses a ConfigMap or Secret, to
+// answer "is this safe to delete". Pod templates of Deployments,
+// StatefulSets, DaemonSets, Jobs and CronJobs are scanned, plus pods no
+// controller owns (controller-owned pods are covered by their workload). For
+// Secrets, ServiceAccount imagePullSecrets count too. References marked
+// optional do not fail pods when the object is missing.
+//
+// Args:
+// - resource_type: "configmap" or "secret" (required)
+// - name (required); namespace defaults to defaultNamespace()
+func K8sReferences(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType := strings.ToLower(getStringArg(args, "resource_type", "resource"))
+	name := getStringArg(args, "name")
+	namespace := getStringArg(args, "namespace")
+
+	var secret bool
+	switch resourceType {
+	case "configmap", "configmaps", "cm":
+	case "secret", "secrets":
+		secret = true
+	case "":
+		return textErrorResult("resource_type is required"), nil, nil
+	default:
+		return textErrorResult(fmt.Sprintf("Error: unsupported resource_type '%s' (expected configmap or secret)", resourceType)), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	kind := "ConfigMap"
+	if secret {
+		kind = "Secret"
+	}
+	exists := true
+	if secret {
+		_, err = cs.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	} else {
+		_, err = cs.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		exists = false
+	}
+
+	rows := []referenceRow{}
+	scan := func(wkind, wname string, spec *v1.PodSpec) {
+		rows = append(rows, podSpecReferences(wkind, wname, spec, name, secret)...)
+	}
+	opts := metav1.ListOptions{}
+	apps := cs.AppsV1()
+	deps, err := apps.Deployments(namespace).List(ctx, opts)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	for i := range deps.Items {
+		scan("Deployment", deps.Items[i].Name, &deps.Items[i].Spec.Template.Spec)
+	}
+	sts, err := apps.StatefulSets(namespace).List(ctx, opts)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	for i := range sts.Items {
+		scan("StatefulSet", sts.Items[i].Name, &sts.Items[i].Spec.Template.Spec)
+	}
+	dss, err := apps.DaemonSets(namespace).List(ctx, opts)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	for i := range dss.Items {
+		scan("DaemonSet", dss.Items[i].Name, &dss.Items[i].Spec.Template.Spec)
+	}
+	cjs, err := cs.BatchV1().CronJobs(namespace).List(ctx, opts)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	for i := range cjs.Items {
+		scan("CronJob", cjs.Items[i].Name, &cjs.Items[i].Spec.JobTemplate.Spec.Template.Spec)
+	}
+	jobs, err := cs.BatchV1().Jobs(namespace).List(ctx, opts)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	for i := range jobs.Items {
+		if metav1.GetControllerOf(&jobs.Items[i]) == nil {
+			scan("Job", jobs.Items[i].Name, &jobs.Items[i].Spec.Template.Spec)
+		}
+	}
+	// Pods of a Deployment's ReplicaSets are covered by the Deployment; pods
+	// of bare ReplicaSets are reported individually.
+	rss, err := apps.ReplicaSets(namespace).List(ctx, opts)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	deploymentRS := map[string]bool{}
+	for i := range rss.Items {
+		if ref := metav1.GetControllerOf(&rss.Items[i]); ref != nil && ref.Kind == "Deployment" {
+			deploymentRS[rss.Items[i].Name] = true
+		}
+	}
+	pods, err := cs.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	for i := range pods.Items {
+		ref := metav1.GetControllerOf(&pods.Items[i])
+		if ref == nil || ref.Kind == "ReplicaSet" && !deploymentRS[ref.Name] {
+			scan("Pod", pods.Items[i].Name, &pods.Items[i].Spec)
+		}
+	}
+	if secret {
+		sas, err := cs.CoreV1().ServiceAccounts(namespace).List(ctx, opts)
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		for _, sa := range sas.Items {
+			for _, s := range sa.ImagePullSecrets {
+				if s.Name == name {
+					rows = append(rows, referenceRow{Kind: "ServiceAccount", Name: sa.Name, Via: "imagePullSecrets"})
+				}
+			}
+		}
+	}
+
+	b, _ := json.MarshalIndent(map[string]any{
+		"kind":          kind,
+		"name":          name,
+		"namespace":     namespace,
+		"exists":        exists,
+		"referenced_by": rows,
+		"in_use":        len(rows) > 0,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// podSpecReferences walks env, envFrom, volumes (projected sources included)
+// and, for Secrets, imagePullSecrets of spec for references to target.
+func podSpecReferences(kind, name string, spec *v1.PodSpec, target string, secret bool) []referenceRow {
+	var rows []referenceRow
+	add := func(container, via string, optional *bool) {
+		rows = append(rows, referenceRow{
+			Kind:      kind,
+			Name:      name,
+			Container: container,
+			Via:       via,
+			Optional:  optional != nil && *optional,
+		})
+	}
+
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, ec := range spec.EphemeralContainers {
+		containers = append(containers, v1.Container(ec.EphemeralContainerCommon))
+	}
+	for _, c := range containers {
+		for _, ef := range c.EnvFrom {
+			if secret && ef.SecretRef != nil && ef.SecretRef.Name == target {
+				add(c.Name, "envFrom", ef.SecretRef.Optional)
+			}
+			if !secret && ef.ConfigMapRef != nil && ef.ConfigMapRef.Name == target {
+				add(c.Name, "envFrom", ef.ConfigMapRef.Optional)
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if s := e.ValueFrom.SecretKeyRef; secret && s != nil && s.Name == target {
+				add(c.Name, fmt.Sprintf("env %s (key %s)", e.Name, s.Key), s.Optional)
+			}
+			if m := e.ValueFrom.ConfigMapKeyRef; !secret && m != nil && m.Name == target {
+				add(c.Name, fmt.Sprintf("env %s (key %s)", e.Name, m.Key), m.Optional)
+			}
+		}
+	}
+
+	for _, vol := range spec.Volumes {
+		if secret && vol.Secret != nil && vol.Secret.SecretName == target {
+			add("", "volume "+vol.Name, vol.Secret.Optional)
+		}
+		if !secret && vol.ConfigMap != nil && vol.ConfigMap.Name == target {
+			add("", "volume "+vol.Name, vol.ConfigMap.Optional)
+		}
+		if vol.Projected == nil {
+			continue
+		}
+		for _, src := range vol.Projected.Sources {
+			if secret && src.Secret != nil && src.Secret.Name == target {
+				add("", "projected volume "+vol.Name, src.Secret.Optional)
+			}
+			if !secret && src.ConfigMap != nil && src.ConfigMap.Name == target {
+				add("", "projected volume "+vol.Name, src.ConfigMap.Optional)
+			}
+		}
+	}
+
+	if secret {
+		for _, s := range spec.ImagePullSecrets {
+			if s.Name == target {
+				add("", "imagePullSecrets", nil)
+			}
+		}
+	}
+	return rows
+}
@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// restartableOwners are the controllers that recreate a deleted pod.
+var restartableOwners = []string{"ReplicaSet", "StatefulSet", "DaemonSet"}
+
+// K8sRestartPod deletes a single pod so its controller recreates it.
+// Bare pods (or pods owned by something else, e.g. a Job) are refused unless
+// force=true, since they would not come back.
+//
+// Args:
+// - pod_name required; namespace defaults to defaultNamespace()
+// - force (bool) default false
+// - wait (bool) default false: wait for the replacement pod to become Ready
+// - timeout (seconds) default 120, max 600, used with wait
+func K8sRestartPod(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	podName, _ := args["pod_name"].(string)
+	namespace, _ := args["namespace"].(string)
+	if strings.TrimSpace(podName) == "" {
+		return textErrorResult("pod_name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	force := boolFromArgs(args, "force", false)
+	wait := boolFromArgs(args, "wait", false)
+	timeout := intFromArgsDefault(args, "timeout", 120)
+	if timeout <= 0 {
+		timeout = 120
+	}
+	if timeout > 600 {
+		timeout = 600
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	owner := metav1.GetControllerOf(pod)
+	controlled := owner != nil && stringInSlice(owner.Kind, restartableOwners)
+	if !controlled && !force {
+		desc := "has no controller"
+		if owner != nil {
+			desc = fmt.Sprintf("is controlled by %s/%s, which does not recreate it", owner.Kind, owner.Name)
+		}
+		return textErrorResult(fmt.Sprintf("Error: pod '%s' %s; deleting it would not bring it back. Pass force=true to delete anyway.", podName, desc)), nil, nil
+	}
+
+	// Remember the owner's current pods so the replacement can be told apart from
+	// siblings that were already running.
+	known := map[types.UID]bool{}
+	if controlled {
+		siblings, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		for i := range siblings.Items {
+			if ref := metav1.GetControllerOf(&siblings.Items[i]); ref != nil && ref.UID == owner.UID {
+				known[siblings.Items[i].UID] = true
+			}
+		}
+	}
+
+	uid := pod.UID
+	if err := cs.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &uid},
+	}); err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	out := map[string]any{
+		"pod":       podName,
+		"namespace": namespace,
+		"deleted":   true,
+	}
+	if owner != nil {
+		out["owner"] = owner.Kind + "/" + owner.Name
+	}
+
+	if wait && controlled {
+		wctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancel()
+		replacement, err := waitForReplacementPod(wctx, cs, namespace, owner.UID, known)
+		if replacement != "" {
+			out["replacement"] = replacement
+		}
+		if err != nil {
+			out["ready"] = false
+			out["wait_error"] = err.Error()
+		} else {
+			out["ready"] = true
+		}
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// waitForReplacementPod polls for a Ready pod owned by ownerUID that is not in known.
+// StatefulSets reuse the pod name, so pods are told apart by UID. On timeout it
+// still returns the replacement's name if one was created.
+func waitForReplacementPod(ctx context.Context, cs *kubernetes.Clientset, namespace string, ownerUID types.UID, known map[types.UID]bool) (string, error) {
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+
+	candidate := ""
+	for {
+		pods, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err == nil {
+			for i := range pods.Items {
+				p := &pods.Items[i]
+				ref := metav1.GetControllerOf(p)
+				if known[p.UID] || ref == nil || ref.UID != ownerUID || p.DeletionTimestamp != nil {
+					continue
+				}
+				candidate = p.Name
+				if podIsReady(p) {
+					return p.Name, nil
+				}
+			}
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return candidate, fmt.Errorf("replacement pod not Ready: %v", ctx.Err())
+		}
+	}
+}
+
+func podIsReady(p *v1.Pod) bool {
+	for _, c := range p.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,297 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type terminationInfo struct {
+	ExitCode   int32  `json:"exit_code"`
+	Signal     int32  `json:"signal,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	Message    string `json:"message,omitempty"`
+	StartedAt  string `json:"started_at,omitempty"`
+	FinishedAt string `json:"finished_at,omitempty"`
+}
+
+type restartDiagnosis struct {
+	Container      string           `json:"container"`
+	RestartCount   int32            `json:"restart_count"`
+	Ready          bool             `json:"ready"`
+	State          string           `json:"state"`
+	WaitingReason  string           `json:"waiting_reason,omitempty"`
+	WaitingMessage string           `json:"waiting_message,omitempty"`
+	LastTerminated *terminationInfo `json:"last_terminated,omitempty"`
+	Diagnosis      string           `json:"diagnosis"`
+}
+
+// K8sWhyRestarting packages the CrashLoopBackOff investigation:
+// containerStatuses (restart count, waiting reason, lastState.terminated) plus the
+// pod's recent events, with a short diagnosis per container.
+//
+// Args: pod_name required, namespace defaults to defaultNamespace(), container optional (all if empty)
+func K8sWhyRestarting(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	podName, _ := args["pod_name"].(string)
+	if strings.TrimSpace(podName) == "" {
+		return textErrorResult("pod_name is required"), nil, nil
+	}
+	container, _ := args["container"].(string)
+	namespace, _ := args["namespace"].(string)
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	ref := &unstructured.Unstructured{}
+	ref.SetName(pod.Name)
+	ref.SetNamespace(pod.Namespace)
+	evs := fetchEventsForObject(ctx, cs, ref)
+
+	probeFailures := 0
+	events := make([]string, 0, len(evs))
+	for _, e := range evs {
+		if e.Reason == "Unhealthy" && strings.Contains(e.Message, "Liveness") {
+			probeFailures++
+		}
+		events = append(events, fmt.Sprintf("%s %s %s: %s", formatEventTime(e), e.Type, e.Reason, e.Message))
+	}
+
+	statuses := append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	var diags []restartDiagnosis
+	for _, st := range statuses {
+		if container != "" && st.Name != container {
+			continue
+		}
+		diags = append(diags, diagnoseContainerStatus(st, probeFailures > 0))
+	}
+	if container != "" && len(diags) == 0 {
+		return textErrorResult(fmt.Sprintf("Error: container '%s' not found in pod '%s'", container, podName)), nil, nil
+	}
+
+	out := map[string]any{
+		"pod":        pod.Name,
+		"namespace":  pod.Namespace,
+		"phase":      string(pod.Status.Phase),
+		"node":       pod.Spec.NodeName,
+		"containers": diags,
+		"events":     events,
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+func diagnoseContainerStatus(st v1.ContainerStatus, livenessFailing bool) restartDiagnosis {
+	d := restartDiagnosis{
+		Container:    st.Name,
+		RestartCount: st.RestartCount,
+		Ready:        st.Ready,
+	}
+
+	switch {
+	case st.State.Running != nil:
+		d.State = "running since " + formatMetaTime(st.State.Running.StartedAt)
+	case st.State.Waiting != nil:
+		d.State = "waiting"
+		d.WaitingReason = st.State.Waiting.Reason
+		d.WaitingMessage = st.State.Waiting.Message
+	case st.State.Terminated != nil:
+		d.State = "terminated"
+	default:
+		d.State = "unknown"
+	}
+
+	if t := st.LastTerminationState.Terminated; t != nil {
+		d.LastTerminated = newTerminationInfo(t)
+	} else if t := st.State.Terminated; t != nil {
+		d.LastTerminated = newTerminationInfo(t)
+	}
+
+	d.Diagnosis = restartHint(d, livenessFailing)
+	return d
+}
+
+func newTerminationInfo(t *v1.ContainerStateTerminated) *terminationInfo {
+	return &terminationInfo{
+		ExitCode:   t.ExitCode,
+		Signal:     t.Signal,
+		Reason:     t.Reason,
+		Message:    t.Message,
+		StartedAt:  formatMetaTime(t.StartedAt),
+		FinishedAt: formatMetaTime(t.FinishedAt),
+	}
+}
+
+// restartHint turns the raw status into the usual first explanation.
+func restartHint(d restartDiagnosis, livenessFailing bool) string {
+	switch d.WaitingReason {
+	case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+		return "Image cannot be pulled: check the image name/tag and imagePullSecrets."
+	case "CreateContainerConfigError":
+		return "Container config is invalid, usually a missing ConfigMap/Secret or key: " + d.WaitingMessage
+	case "CreateContainerError", "RunContainerError":
+		return "The runtime could not start the container: " + d.WaitingMessage
+	}
+
+	t := d.LastTerminated
+	if t == nil {
+		if d.RestartCount == 0 {
+			return "No restarts recorded."
+		}
+		return "Restarted, but no termination details are retained."
+	}
+
+	switch {
+	case t.Reason == "OOMKilled":
+		return "Killed for exceeding its memory limit (OOMKilled): raise the memory limit or reduce usage."
+	case t.ExitCode == 137 && livenessFailing:
+		return "Killed (SIGKILL) after liveness probe failures: check the probe settings and app startup time."
+	case t.ExitCode == 137:
+		return "Killed with SIGKILL (exit 137): liveness probe, eviction or an external kill."
+	case t.ExitCode == 143:
+		return "Terminated with SIGTERM (exit 143): stopped by the kubelet, e.g. probe failure or pod shutdown."
+	case t.ExitCode == 126 || t.ExitCode == 127:
+		return fmt.Sprintf("Exit %d: the command is not found or not executable; check command/args and the image.", t.ExitCode)
+	case t.ExitCode == 0:
+		return "The process exited successfully; with restartPolicy Always a finishing command is restarted repeatedly."
+	}
+	return fmt.Sprintf("The application exited with code %d (%s): check logs with previous=true.", t.ExitCode, t.Reason)
+}
+
+// restartEvent is a kubelet event about one container's restarts (BackOff,
+// Killing, Unhealthy, ...), with how often it was seen.
+type restartEvent struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	Count   int32  `json:"count,omitempty"`
+	Last    string `json:"last"`
+}
+
+// K8sRestartHistory complements previous=true logs for containers that crashed
+// many times. The API keeps only one previous log and one lastState, so this
+// returns that log (tail) together with everything the pod still records about
+// the restarts: restart count, current state, the retained termination(s) with
+// exit code and reason, and the container's restart-related events.
+//
+// Args:
+// - pod_name (required); namespace defaults to defaultNamespace()
+// - container: defaults to the pod's default container
+// - tail: lines of the previous log, default 200 (max 10000)
+func K8sRestartHistory(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	podName, _ := args["pod_name"].(string)
+	container, _ := args["container"].(string)
+	namespace, _ := args["namespace"].(string)
+	tail := int64(intFromArgsDefault(args, "tail", 200))
+
+	if strings.TrimSpace(podName) == "" {
+		return textErrorResult("pod_name is required"), nil, nil
+	}
+	if tail <= 0 || tail > maxLogTailLines {
+		return textErrorResult(fmt.Sprintf("Error: tail must be between 1 and %d", maxLogTailLines)), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	pod, err := cs.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	if container == "" {
+		container = podDefaultContainerName(pod)
+	}
+
+	var status *v1.ContainerStatus
+	for _, list := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for i := range list {
+			if list[i].Name == container {
+				status = &list[i]
+			}
+		}
+	}
+	if status == nil {
+		return textErrorResult(fmt.Sprintf("Error: container '%s' has no status in pod '%s'", container, podName)), nil, nil
+	}
+
+	d := diagnoseContainerStatus(*status, false)
+	terminations := []terminationInfo{}
+	if t := status.LastTerminationState.Terminated; t != nil {
+		terminations = append(terminations, *newTerminationInfo(t))
+	}
+	if t := status.State.Terminated; t != nil {
+		terminations = append(terminations, *newTerminationInfo(t))
+	}
+
+	ref := &unstructured.Unstructured{}
+	ref.SetName(pod.Name)
+	ref.SetNamespace(pod.Namespace)
+	events := []restartEvent{}
+	livenessFailing := false
+	for _, e := range fetchEventsForObject(ctx, cs, ref) {
+		if !strings.HasSuffix(e.FieldPath, "{"+container+"}") {
+			continue
+		}
+		switch e.Reason {
+		case "BackOff", "Killing", "Unhealthy", "Failed", "Started", "Pulled":
+		default:
+			continue
+		}
+		if e.Reason == "Unhealthy" && strings.Contains(e.Message, "Liveness") {
+			livenessFailing = true
+		}
+		events = append(events, restartEvent{Reason: e.Reason, Message: e.Message, Count: e.Count, Last: formatEventTime(e)})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Last > events[j].Last })
+
+	out := map[string]any{
+		"pod":           pod.Name,
+		"namespace":     pod.Namespace,
+		"container":     container,
+		"restart_count": status.RestartCount,
+		"state":         d.State,
+		"terminations":  terminations,
+		"events":        events,
+		"diagnosis":     restartHint(d, livenessFailing),
+	}
+	if status.RestartCount > int32(len(terminations)) {
+		out["note"] = fmt.Sprintf("%d restarts, but the API retains only the last termination and previous log; events (with counts) are the remaining record", status.RestartCount)
+	}
+
+	if status.RestartCount > 0 {
+		b, err := cs.CoreV1().Pods(namespace).GetLogs(podName, &v1.PodLogOptions{
+			Container: container,
+			Previous:  true,
+			TailLines: &tail,
+		}).DoRaw(ctx)
+		if err != nil {
+			out["previous_log_error"] = formatLogErr(err)
+		} else {
+			out["previous_log"] = string(b)
+		}
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
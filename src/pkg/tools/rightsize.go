@@ -0,0 +1,190 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type rightsizeRow struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	QOSClass  string `json:"qos_class"`
+
+	CPUUsage   string `json:"cpu_usage"`
+	CPURequest string `json:"cpu_request,omitempty"`
+	CPULimit   string `json:"cpu_limit,omitempty"`
+	MemUsage   string `json:"memory_usage"`
+	MemRequest string `json:"memory_request,omitempty"`
+	MemLimit   string `json:"memory_limit,omitempty"`
+
+	// Usage as a percentage of request/limit; nil when not set.
+	CPURequestPct *int64 `json:"cpu_request_pct,omitempty"`
+	CPULimitPct   *int64 `json:"cpu_limit_pct,omitempty"`
+	MemRequestPct *int64 `json:"memory_request_pct,omitempty"`
+	MemLimitPct   *int64 `json:"memory_limit_pct,omitempty"`
+
+	Flags []string `json:"flags,omitempty"`
+}
+
+// K8sRightsize joins live container usage (metrics.k8s.io, as in k8s_top_pods)
+// with each container's requests/limits and flags over- and under-provisioning.
+// Metrics are a single sample, so treat the flags as hints, not a verdict.
+//
+// Args:
+// - namespace defaults to defaultNamespace(); all_namespaces (bool); label_selector
+// - low_pct: usage below this % of the request is flagged over-provisioned (default 20)
+// - high_pct: usage above this % of the limit is flagged at risk (default 90)
+// - flagged_only (bool): only return containers with at least one flag
+func K8sRightsize(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	namespace, _ := args["namespace"].(string)
+	allNamespaces := boolFromArgs(args, "all_namespaces", false)
+	selector := getStringArg(args, "label_selector", "selector")
+	lowPct := int64(intFromArgsDefault(args, "low_pct", 20))
+	highPct := int64(intFromArgsDefault(args, "high_pct", 90))
+	flaggedOnly := boolFromArgs(args, "flagged_only", false)
+
+	if allNamespaces {
+		namespace = metav1.NamespaceAll
+	} else if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	pods, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	metrics, err := podMetricsByKey(ctx, dyn, namespace, allNamespaces)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error() + " (is metrics-server installed?)"), nil, nil
+	}
+
+	rows := []rightsizeRow{}
+	noMetrics := 0
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if p.Status.Phase != v1.PodRunning {
+			continue
+		}
+		m := metrics[p.Namespace+"/"+p.Name]
+		if m == nil {
+			noMetrics++
+			continue
+		}
+		usage := map[string]containerUsage{}
+		for _, u := range containerUsages(m) {
+			usage[u.name] = u
+		}
+		qos := string(podQOSClass(p))
+		oomKilled := map[string]bool{}
+		for _, st := range p.Status.ContainerStatuses {
+			if t := st.LastTerminationState.Terminated; t != nil && t.Reason == "OOMKilled" {
+				oomKilled[st.Name] = true
+			}
+		}
+
+		for _, c := range p.Spec.Containers {
+			u, ok := usage[c.Name]
+			if !ok {
+				continue
+			}
+			r := rightsizeRow{
+				Namespace: p.Namespace,
+				Pod:       p.Name,
+				Container: c.Name,
+				QOSClass:  qos,
+				CPUUsage:  fmt.Sprintf("%dm", u.milli),
+				MemUsage:  formatBytesHuman(u.bytes),
+			}
+
+			if q, ok := c.Resources.Requests[v1.ResourceCPU]; ok && !q.IsZero() {
+				r.CPURequest = q.String()
+				r.CPURequestPct = pct(u.milli, q.MilliValue())
+			}
+			if q, ok := c.Resources.Limits[v1.ResourceCPU]; ok && !q.IsZero() {
+				r.CPULimit = q.String()
+				r.CPULimitPct = pct(u.milli, q.MilliValue())
+			}
+			if q, ok := c.Resources.Requests[v1.ResourceMemory]; ok && !q.IsZero() {
+				r.MemRequest = q.String()
+				r.MemRequestPct = pct(u.bytes, q.Value())
+			}
+			if q, ok := c.Resources.Limits[v1.ResourceMemory]; ok && !q.IsZero() {
+				r.MemLimit = q.String()
+				r.MemLimitPct = pct(u.bytes, q.Value())
+			}
+
+			if r.CPURequest == "" {
+				r.Flags = append(r.Flags, "no cpu request (BestEffort for scheduling)")
+			} else if *r.CPURequestPct < lowPct {
+				r.Flags = append(r.Flags, fmt.Sprintf("cpu over-provisioned: using %d%% of request", *r.CPURequestPct))
+			} else if *r.CPURequestPct > 100 {
+				r.Flags = append(r.Flags, fmt.Sprintf("cpu under-requested: using %d%% of request", *r.CPURequestPct))
+			}
+			if r.MemRequest == "" {
+				r.Flags = append(r.Flags, "no memory request")
+			} else if *r.MemRequestPct < lowPct {
+				r.Flags = append(r.Flags, fmt.Sprintf("memory over-provisioned: using %d%% of request", *r.MemRequestPct))
+			} else if *r.MemRequestPct > 100 {
+				r.Flags = append(r.Flags, fmt.Sprintf("memory under-requested: using %d%% of request", *r.MemRequestPct))
+			}
+			if r.CPULimitPct != nil && *r.CPULimitPct >= highPct {
+				r.Flags = append(r.Flags, fmt.Sprintf("cpu throttling likely: at %d%% of limit", *r.CPULimitPct))
+			}
+			if r.MemLimitPct != nil && *r.MemLimitPct >= highPct {
+				r.Flags = append(r.Flags, fmt.Sprintf("OOM risk: at %d%% of memory limit", *r.MemLimitPct))
+			}
+			if oomKilled[c.Name] {
+				r.Flags = append(r.Flags, "was OOMKilled on its last termination")
+			}
+
+			if flaggedOnly && len(r.Flags) == 0 {
+				continue
+			}
+			rows = append(rows, r)
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if len(rows[i].Flags) != len(rows[j].Flags) {
+			return len(rows[i].Flags) > len(rows[j].Flags)
+		}
+		if rows[i].Namespace != rows[j].Namespace {
+			return rows[i].Namespace < rows[j].Namespace
+		}
+		if rows[i].Pod != rows[j].Pod {
+			return rows[i].Pod < rows[j].Pod
+		}
+		return rows[i].Container < rows[j].Container
+	})
+
+	out := map[string]any{"containers": rows}
+	if noMetrics > 0 {
+		out["pods_without_metrics"] = noMetrics
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+func pct(used, of int64) *int64 {
+	if of <= 0 {
+		return nil
+	}
+	p := used * 100 / of
+	return &p
+}
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
@@ -13,6 +14,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 )
 
 // K8sRolloutStatus ports k8s_rollout_status(resource_type, name, namespace)
@@ -28,7 +30,7 @@ func K8sRolloutStatus(ctx context.Context, _ *mcp.CallToolRequest, args map[stri
 		return textErrorResult("name is required"), nil, nil
 	}
 	if namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	cs, err := getClient()
@@ -36,11 +38,23 @@ func K8sRolloutStatus(ctx context.Context, _ *mcp.CallToolRequest, args map[stri
 		return textErrorResult(err.Error()), nil, nil
 	}
 
+	status, err := rolloutStatusFor(ctx, cs, resourceType, name, namespace)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	b, _ := json.MarshalIndent(status, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// rolloutStatusFor builds the k8s_rollout_status payload. status["status"] is
+// "complete" or "in progress"; errors are already formatted for tool output.
+func rolloutStatusFor(ctx context.Context, cs *kubernetes.Clientset, resourceType, name, namespace string) (map[string]any, error) {
 	switch strings.ToLower(resourceType) {
 	case "deployment":
 		d, err := cs.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
-			return textErrorResult(formatK8sErr(err)), nil, nil
+			return nil, errors.New(formatK8sErr(err))
 		}
 
 		replicas := int32(0)
@@ -94,13 +108,12 @@ func K8sRolloutStatus(ctx context.Context, _ *mcp.CallToolRequest, args map[stri
 			status["message"] = msg
 		}
 
-		b, _ := json.MarshalIndent(status, "", "  ")
-		return textOKResult(string(b)), nil, nil
+		return status, nil
 
 	case "daemonset":
 		ds, err := cs.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
-			return textErrorResult(formatK8sErr(err)), nil, nil
+			return nil, errors.New(formatK8sErr(err))
 		}
 
 		conds := make([]map[string]any, 0, len(ds.Status.Conditions))
@@ -140,13 +153,12 @@ func K8sRolloutStatus(ctx context.Context, _ *mcp.CallToolRequest, args map[stri
 			status["message"] = msg
 		}
 
-		b, _ := json.MarshalIndent(status, "", "  ")
-		return textOKResult(string(b)), nil, nil
+		return status, nil
 
 	case "statefulset":
 		ss, err := cs.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
-			return textErrorResult(formatK8sErr(err)), nil, nil
+			return nil, errors.New(formatK8sErr(err))
 		}
 
 		replicas := ss.Status.Replicas
@@ -177,11 +189,10 @@ func K8sRolloutStatus(ctx context.Context, _ *mcp.CallToolRequest, args map[stri
 			status["message"] = msg
 		}
 
-		b, _ := json.MarshalIndent(status, "", "  ")
-		return textOKResult(string(b)), nil, nil
+		return status, nil
 
 	default:
-		return textErrorResult(fmt.Sprintf("Error: resource type '%s' does not support rollout status", resourceType)), nil, nil
+		return nil, fmt.Errorf("Error: resource type '%s' does not support rollout status", resourceType)
 	}
 }
 
@@ -199,7 +210,7 @@ func K8sRolloutHistory(ctx context.Context, _ *mcp.CallToolRequest, args map[str
 		return textErrorResult("name is required"), nil, nil
 	}
 	if namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	cs, err := getClient()
@@ -214,17 +225,11 @@ func K8sRolloutHistory(ctx context.Context, _ *mcp.CallToolRequest, args map[str
 			return textErrorResult(formatK8sErr(err)), nil, nil
 		}
 
-		selector := labelsToSelector(dep.Spec.Selector.MatchLabels)
-		rss, err := cs.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		rss, err := deploymentRevisions(ctx, cs, dep)
 		if err != nil {
 			return textErrorResult(formatK8sErr(err)), nil, nil
 		}
 
-		// Sort by deployment.kubernetes.io/revision desc
-		sort.Slice(rss.Items, func(i, j int) bool {
-			return revisionNumber(&rss.Items[i]) > revisionNumber(&rss.Items[j])
-		})
-
 		type histEntry struct {
 			Revision    string
 			ReplicaSet  string
@@ -238,8 +243,8 @@ func K8sRolloutHistory(ctx context.Context, _ *mcp.CallToolRequest, args map[str
 
 		var history []histEntry
 
-		for i := range rss.Items {
-			rs := &rss.Items[i]
+		for i := range rss {
+			rs := &rss[i]
 			rev := revisionString(rs)
 			if revision != "" && revision != rev {
 				continue
@@ -369,7 +374,7 @@ func K8sRolloutUndo(ctx context.Context, _ *mcp.CallToolRequest, args map[string
 		return textErrorResult("name is required"), nil, nil
 	}
 	if namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	cs, err := getClient()
@@ -454,6 +459,10 @@ func K8sRolloutUndo(ctx context.Context, _ *mcp.CallToolRequest, args map[string
 }
 
 // K8sRolloutRestart ports k8s_rollout_restart(resource_type, name, namespace)
+//
+// With wait=true it keeps polling rollout status after the patch until the
+// controller has observed the new generation and the rollout is complete, or
+// timeout (seconds, default 300, max 1800) elapses.
 func K8sRolloutRestart(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	resourceType, _ := args["resource_type"].(string)
 	name, _ := args["name"].(string)
@@ -466,7 +475,15 @@ func K8sRolloutRestart(ctx context.Context, _ *mcp.CallToolRequest, args map[str
 		return textErrorResult("name is required"), nil, nil
 	}
 	if namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
+	}
+	wait := boolFromArgs(args, "wait", false)
+	timeout := intFromArgsDefault(args, "timeout", 300)
+	if timeout <= 0 {
+		timeout = 300
+	}
+	if timeout > 1800 {
+		timeout = 1800
 	}
 
 	cs, err := getClient()
@@ -477,39 +494,141 @@ func K8sRolloutRestart(ctx context.Context, _ *mcp.CallToolRequest, args map[str
 	now := time.Now().UTC().Format(time.RFC3339Nano)
 	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`, now))
 
+	var generation int64
 	switch strings.ToLower(resourceType) {
 	case "deployment":
-		_, err := cs.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		d, err := cs.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
 		if err != nil {
 			return textErrorResult(formatK8sErr(err)), nil, nil
 		}
-		return textOKResult(fmt.Sprintf("Restart of %s/%s initiated successfully", resourceType, name)), nil, nil
+		generation = d.Generation
 
 	case "daemonset":
-		_, err := cs.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		ds, err := cs.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
 		if err != nil {
 			return textErrorResult(formatK8sErr(err)), nil, nil
 		}
-		return textOKResult(fmt.Sprintf("Restart of %s/%s initiated successfully", resourceType, name)), nil, nil
+		generation = ds.Generation
 
 	case "statefulset":
-		_, err := cs.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		ss, err := cs.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
 		if err != nil {
 			return textErrorResult(formatK8sErr(err)), nil, nil
 		}
-		return textOKResult(fmt.Sprintf("Restart of %s/%s initiated successfully", resourceType, name)), nil, nil
+		generation = ss.Generation
 
 	default:
 		return textErrorResult(fmt.Sprintf("Error: resource type '%s' restart not available through API", resourceType)), nil, nil
 	}
+
+	if !wait {
+		return textOKResult(fmt.Sprintf("Restart of %s/%s initiated successfully", resourceType, name)), nil, nil
+	}
+
+	wctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+	status, err := waitRolloutComplete(wctx, cs, resourceType, name, namespace, generation)
+
+	out := map[string]any{
+		"restarted_at": now,
+		"status":       status,
+	}
+	switch {
+	case err == nil:
+		out["result"] = "complete"
+	case ctx.Err() != nil:
+		out["result"] = "cancelled"
+	case wctx.Err() != nil:
+		out["result"] = "timeout"
+	default:
+		out["result"] = "error"
+		out["error"] = err.Error()
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	if out["result"] != "complete" {
+		return textErrorResult(string(b)), nil, nil
+	}
+	return textOKResult(string(b)), nil, nil
+}
+
+// waitRolloutComplete polls rolloutStatusFor until the controller has observed
+// generation and reports the rollout complete. The last status is returned even
+// when ctx expires first.
+func waitRolloutComplete(ctx context.Context, cs *kubernetes.Clientset, resourceType, name, namespace string, generation int64) (map[string]any, error) {
+	t := time.NewTicker(2 * time.Second)
+	defer t.Stop()
+
+	var last map[string]any
+	for {
+		observed, err := workloadObservedGeneration(ctx, cs, resourceType, name, namespace)
+		if err != nil && ctx.Err() == nil {
+			return last, err
+		}
+		if err == nil && observed >= generation {
+			st, err := rolloutStatusFor(ctx, cs, resourceType, name, namespace)
+			if err != nil && ctx.Err() == nil {
+				return last, err
+			}
+			if st != nil {
+				last = st
+				if st["status"] == "complete" {
+					return last, nil
+				}
+			}
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return last, ctx.Err()
+		}
+	}
+}
+
+func workloadObservedGeneration(ctx context.Context, cs *kubernetes.Clientset, resourceType, name, namespace string) (int64, error) {
+	switch strings.ToLower(resourceType) {
+	case "deployment":
+		d, err := cs.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return 0, errors.New(formatK8sErr(err))
+		}
+		return d.Status.ObservedGeneration, nil
+	case "daemonset":
+		ds, err := cs.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return 0, errors.New(formatK8sErr(err))
+		}
+		return ds.Status.ObservedGeneration, nil
+	case "statefulset":
+		ss, err := cs.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return 0, errors.New(formatK8sErr(err))
+		}
+		return ss.Status.ObservedGeneration, nil
+	}
+	return 0, fmt.Errorf("Error: resource type '%s' has no rollout status", resourceType)
 }
 
 // K8sRolloutPause ports k8s_rollout_pause(resource_type, name, namespace)
 func K8sRolloutPause(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	return k8sRolloutSetPaused(ctx, args, true)
+}
+
+// K8sRolloutResume is the inverse of K8sRolloutPause.
+func K8sRolloutResume(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	return k8sRolloutSetPaused(ctx, args, false)
+}
+
+func k8sRolloutSetPaused(ctx context.Context, args map[string]any, paused bool) (*mcp.CallToolResult, any, error) {
 	resourceType, _ := args["resource_type"].(string)
 	name, _ := args["name"].(string)
 	namespace, _ := args["namespace"].(string)
 
+	verb := "pause"
+	if !paused {
+		verb = "resume"
+	}
+
 	if strings.TrimSpace(resourceType) == "" {
 		return textErrorResult("resource_type is required"), nil, nil
 	}
@@ -517,11 +636,11 @@ func K8sRolloutPause(ctx context.Context, _ *mcp.CallToolRequest, args map[strin
 		return textErrorResult("name is required"), nil, nil
 	}
 	if namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	if strings.ToLower(resourceType) != "deployment" {
-		return textErrorResult(fmt.Sprintf("Error: resource type '%s' pause not available through API", resourceType)), nil, nil
+		return textErrorResult(fmt.Sprintf("Error: resource type '%s' %s not available through API", resourceType, verb)), nil, nil
 	}
 
 	cs, err := getClient()
@@ -529,17 +648,61 @@ func K8sRolloutPause(ctx context.Context, _ *mcp.CallToolRequest, args map[strin
 		return textErrorResult(err.Error()), nil, nil
 	}
 
-	patch := []byte(`{"spec":{"paused":true}}`)
-	_, err = cs.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
-	if err != nil {
+	if err := setDeploymentPaused(ctx, cs, namespace, name, paused); err != nil {
 		return textErrorResult(formatK8sErr(err)), nil, nil
 	}
 
-	return textOKResult(fmt.Sprintf("Paused rollout of %s/%s successfully", resourceType, name)), nil, nil
+	if paused {
+		return textOKResult(fmt.Sprintf("Paused rollout of %s/%s successfully", resourceType, name)), nil, nil
+	}
+	return textOKResult(fmt.Sprintf("Resumed rollout of %s/%s successfully", resourceType, name)), nil, nil
+}
+
+func setDeploymentPaused(ctx context.Context, cs *kubernetes.Clientset, namespace, name string, paused bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"paused":%t}}`, paused))
+	_, err := cs.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
 }
 
 // ---- helpers ----
 
+// deploymentRevisions lists the Deployment's ReplicaSets, newest revision first.
+func deploymentRevisions(ctx context.Context, cs *kubernetes.Clientset, dep *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	selector := labelsToSelector(dep.Spec.Selector.MatchLabels)
+	rss, err := cs.AppsV1().ReplicaSets(dep.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the Deployment's own ReplicaSets; another workload may share labels.
+	items := rss.Items[:0]
+	for _, rs := range rss.Items {
+		if ref := metav1.GetControllerOf(&rs); ref == nil || ref.UID == dep.UID {
+			items = append(items, rs)
+		}
+	}
+
+	// Sort by deployment.kubernetes.io/revision desc
+	sort.Slice(items, func(i, j int) bool {
+		return revisionNumber(&items[i]) > revisionNumber(&items[j])
+	})
+	return items, nil
+}
+
+// currentReplicaSet picks the ReplicaSet carrying the deployment's revision
+// annotation, or the newest one; rss is deploymentRevisions output and must
+// not be empty.
+func currentReplicaSet(dep *appsv1.Deployment, rss []appsv1.ReplicaSet) *appsv1.ReplicaSet {
+	if rev := dep.Annotations["deployment.kubernetes.io/revision"]; rev != "" {
+		for i := range rss {
+			if revisionString(&rss[i]) == rev {
+				return &rss[i]
+			}
+		}
+	}
+	return &rss[0]
+}
+
 func labelsToSelector(m map[string]string) string {
 	if len(m) == 0 {
 		return ""
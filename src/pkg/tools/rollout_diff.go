@@ -0,0 +1,221 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// K8sRolloutDiff answers "what changed between these two rollouts" for a
+// Deployment by comparing the pod templates of two revisions' ReplicaSets:
+// images, env, resources, command/args, ports, probes and template metadata.
+//
+// Args:
+// - name (required); namespace defaults to defaultNamespace()
+// - to_revision: defaults to the current revision
+// - from_revision: defaults to the revision before to_revision
+func K8sRolloutDiff(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	name := getStringArg(args, "name")
+	namespace := getStringArg(args, "namespace")
+	fromRev := fmtAny(args["from_revision"])
+	toRev := fmtAny(args["to_revision"])
+
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	dep, err := cs.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	rss, err := deploymentRevisions(ctx, cs, dep)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	if len(rss) == 0 {
+		return textErrorResult("Error: no rollout history found"), nil, nil
+	}
+
+	// rss is newest first.
+	if toRev == "" {
+		if cur := dep.Annotations["deployment.kubernetes.io/revision"]; cur != "" {
+			toRev = cur
+		} else {
+			toRev = revisionString(&rss[0])
+		}
+	}
+	toIdx := revisionIndex(rss, toRev)
+	if toIdx < 0 {
+		return textErrorResult(fmt.Sprintf("Error: revision %s not found", toRev)), nil, nil
+	}
+
+	var fromIdx int
+	if fromRev == "" {
+		if toIdx+1 >= len(rss) {
+			return textErrorResult(fmt.Sprintf("Error: revision %s has no previous revision to compare with", toRev)), nil, nil
+		}
+		fromIdx = toIdx + 1
+		fromRev = revisionString(&rss[fromIdx])
+	} else if fromIdx = revisionIndex(rss, fromRev); fromIdx < 0 {
+		return textErrorResult(fmt.Sprintf("Error: revision %s not found", fromRev)), nil, nil
+	}
+
+	from, to := &rss[fromIdx], &rss[toIdx]
+	changes := diffFlat(flattenPodTemplate(from.Spec.Template), flattenPodTemplate(to.Spec.Template))
+
+	out := map[string]any{
+		"deployment":      name,
+		"namespace":       namespace,
+		"from_revision":   fromRev,
+		"from_replicaset": from.Name,
+		"to_revision":     toRev,
+		"to_replicaset":   to.Name,
+		"replicas": map[string]any{
+			"from": replicasOf(from),
+			"to":   replicasOf(to),
+		},
+		"changes": changes,
+	}
+	if fc := from.Annotations["kubernetes.io/change-cause"]; fc != "" {
+		out["from_change_cause"] = fc
+	}
+	if tc := to.Annotations["kubernetes.io/change-cause"]; tc != "" {
+		out["to_change_cause"] = tc
+	}
+	if len(changes) == 0 {
+		out["note"] = "pod templates are identical"
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+func revisionIndex(rss []appsv1.ReplicaSet, rev string) int {
+	for i := range rss {
+		if revisionString(&rss[i]) == rev {
+			return i
+		}
+	}
+	return -1
+}
+
+func replicasOf(rs *appsv1.ReplicaSet) int32 {
+	if rs.Spec.Replicas == nil {
+		return 1
+	}
+	return *rs.Spec.Replicas
+}
+
+// flattenPodTemplate reduces a pod template to "path -> value" pairs for the
+// fields that usually explain a rollout. pod-template-hash is left out since
+// it always differs between revisions.
+func flattenPodTemplate(t v1.PodTemplateSpec) map[string]string {
+	flat := map[string]string{}
+	for k, v := range t.Labels {
+		if k == appsv1.DefaultDeploymentUniqueLabelKey {
+			continue
+		}
+		flat["template.labels."+k] = v
+	}
+	for k, v := range t.Annotations {
+		flat["template.annotations."+k] = v
+	}
+	if t.Spec.ServiceAccountName != "" {
+		flat["spec.serviceAccountName"] = t.Spec.ServiceAccountName
+	}
+	for k, v := range t.Spec.NodeSelector {
+		flat["spec.nodeSelector."+k] = v
+	}
+	for _, v := range t.Spec.Volumes {
+		b, _ := json.Marshal(v.VolumeSource)
+		flat["spec.volumes."+v.Name] = string(b)
+	}
+
+	flattenContainers := func(prefix string, containers []v1.Container) {
+		for _, c := range containers {
+			p := fmt.Sprintf("%s[%s]", prefix, c.Name)
+			flat[p+".image"] = c.Image
+			if len(c.Command) > 0 {
+				flat[p+".command"] = strings.Join(c.Command, " ")
+			}
+			if len(c.Args) > 0 {
+				flat[p+".args"] = strings.Join(c.Args, " ")
+			}
+			for _, e := range c.Env {
+				if e.ValueFrom != nil {
+					b, _ := json.Marshal(e.ValueFrom)
+					flat[p+".env."+e.Name] = "valueFrom:" + string(b)
+				} else {
+					flat[p+".env."+e.Name] = e.Value
+				}
+			}
+			for _, ef := range c.EnvFrom {
+				b, _ := json.Marshal(ef)
+				flat[p+".envFrom."+string(b)] = "present"
+			}
+			for r, q := range c.Resources.Requests {
+				flat[p+".resources.requests."+string(r)] = q.String()
+			}
+			for r, q := range c.Resources.Limits {
+				flat[p+".resources.limits."+string(r)] = q.String()
+			}
+			for _, port := range c.Ports {
+				flat[fmt.Sprintf("%s.ports.%d/%s", p, port.ContainerPort, port.Protocol)] = port.Name
+			}
+			for _, m := range c.VolumeMounts {
+				flat[p+".volumeMounts."+m.MountPath] = m.Name
+			}
+			for probe, pr := range map[string]*v1.Probe{"livenessProbe": c.LivenessProbe, "readinessProbe": c.ReadinessProbe, "startupProbe": c.StartupProbe} {
+				if pr != nil {
+					b, _ := json.Marshal(pr)
+					flat[p+"."+probe] = string(b)
+				}
+			}
+		}
+	}
+	flattenContainers("containers", t.Spec.Containers)
+	flattenContainers("initContainers", t.Spec.InitContainers)
+	return flat
+}
+
+// diffFlat reports added, removed and changed keys, sorted by field.
+func diffFlat(from, to map[string]string) []fieldChange {
+	changes := []fieldChange{}
+	for k, fv := range from {
+		tv, ok := to[k]
+		switch {
+		case !ok:
+			changes = append(changes, fieldChange{Field: k, From: fv, To: "<removed>"})
+		case tv != fv:
+			changes = append(changes, fieldChange{Field: k, From: fv, To: tv})
+		}
+	}
+	for k, tv := range to {
+		if _, ok := from[k]; !ok {
+			changes = append(changes, fieldChange{Field: k, From: "<unset>", To: tv})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
@@ -0,0 +1,406 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+const maxTimelineEntries = 500
+
+type timelineEntry struct {
+	Time    string `json:"time"`
+	Source  string `json:"source"`
+	Object  string `json:"object,omitempty"`
+	Message string `json:"message"`
+}
+
+// K8sRolloutWatch watches a workload, its pods and the related events until the
+// rollout completes or the timeout elapses, and returns the timeline.
+//
+// Args:
+// - resource_type (deployment|statefulset|daemonset), name required
+// - namespace defaults to defaultNamespace()
+// - timeout (seconds) default 120, max 600
+func K8sRolloutWatch(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType, _ := args["resource_type"].(string)
+	name, _ := args["name"].(string)
+	namespace, _ := args["namespace"].(string)
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	timeout := intFromArgsDefault(args, "timeout", 120)
+	if timeout <= 0 {
+		timeout = 120
+	}
+	if timeout > 600 {
+		timeout = 600
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	workload, sel, err := workloadObject(ctx, cs, resourceType, name, namespace)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	selector, err := selectorString(resourceType, name, sel)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	tree, err := newRolloutTree(ctx, cs, workload, selector)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	wctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	var timeline []timelineEntry
+	add := func(e timelineEntry) {
+		if len(timeline) < maxTimelineEntries {
+			timeline = append(timeline, e)
+		}
+	}
+
+	status, err := rolloutStatusFor(wctx, cs, resourceType, name, namespace)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	lastMsg := fmt.Sprint(status["message"])
+	add(timelineEntry{Time: nowRFC3339(), Source: "rollout", Object: name, Message: lastMsg})
+
+	// Recent related events give context for a rollout that was already stuck.
+	evList, err := cs.CoreV1().Events(namespace).List(wctx, metav1.ListOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	var earlier []*v1.Event
+	for i := range evList.Items {
+		// Only what is known now: a GET per unrelated event would be wasteful.
+		if tree.owned[evList.Items[i].InvolvedObject.UID] {
+			earlier = append(earlier, &evList.Items[i])
+		}
+	}
+	sort.Slice(earlier, func(i, j int) bool { return eventTimestamp(earlier[i]) < eventTimestamp(earlier[j]) })
+	if len(earlier) > 20 {
+		earlier = earlier[len(earlier)-20:]
+	}
+	for _, e := range earlier {
+		add(eventTimelineEntry(e))
+	}
+
+	if status["status"] == "complete" {
+		return rolloutWatchResult(status, "complete", timeline)
+	}
+
+	wlWatch, err := watchWorkload(wctx, cs, resourceType, name, namespace)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	defer wlWatch.Stop()
+
+	podWatch, err := cs.CoreV1().Pods(namespace).Watch(wctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	defer podWatch.Stop()
+
+	evWatch, err := cs.CoreV1().Events(namespace).Watch(wctx, metav1.ListOptions{ResourceVersion: evList.ResourceVersion})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	defer evWatch.Stop()
+
+	wlCh, podCh, evCh := wlWatch.ResultChan(), podWatch.ResultChan(), evWatch.ResultChan()
+	podStates := map[string]string{}
+
+	for wlCh != nil || podCh != nil || evCh != nil {
+		select {
+		case <-wctx.Done():
+			result := "timeout"
+			if ctx.Err() != nil {
+				result = "cancelled"
+			}
+			return rolloutWatchResult(status, result, timeline)
+
+		case _, ok := <-wlCh:
+			if !ok {
+				wlCh = nil
+				continue
+			}
+			st, err := rolloutStatusFor(wctx, cs, resourceType, name, namespace)
+			if err != nil {
+				add(timelineEntry{Time: nowRFC3339(), Source: "rollout", Object: name, Message: err.Error()})
+				continue
+			}
+			status = st
+			if msg := fmt.Sprint(st["message"]); msg != lastMsg {
+				lastMsg = msg
+				add(timelineEntry{Time: nowRFC3339(), Source: "rollout", Object: name, Message: msg})
+			}
+			if st["status"] == "complete" {
+				return rolloutWatchResult(status, "complete", timeline)
+			}
+
+		case ev, ok := <-podCh:
+			if !ok {
+				podCh = nil
+				continue
+			}
+			pod, ok := ev.Object.(*v1.Pod)
+			if !ok || pod == nil || !tree.owns(wctx, pod) {
+				continue
+			}
+			state := podStateSummary(pod)
+			if ev.Type == watch.Deleted {
+				state = "deleted"
+			}
+			if podStates[pod.Name] == state {
+				continue
+			}
+			podStates[pod.Name] = state
+			add(timelineEntry{Time: nowRFC3339(), Source: "pod", Object: pod.Name, Message: state})
+
+		case ev, ok := <-evCh:
+			if !ok {
+				evCh = nil
+				continue
+			}
+			e, ok := ev.Object.(*v1.Event)
+			if !ok || e == nil || ev.Type == watch.Deleted || !tree.relates(wctx, e) {
+				continue
+			}
+			add(eventTimelineEntry(e))
+		}
+	}
+
+	return rolloutWatchResult(status, "watch ended", timeline)
+}
+
+func rolloutWatchResult(status map[string]any, result string, timeline []timelineEntry) (*mcp.CallToolResult, any, error) {
+	out := map[string]any{
+		"result":   result,
+		"status":   status,
+		"timeline": timeline,
+	}
+	if len(timeline) >= maxTimelineEntries {
+		out["truncated"] = true
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// workloadSelector returns the pod label selector of a deployment/statefulset/daemonset.
+func workloadSelector(ctx context.Context, cs *kubernetes.Clientset, resourceType, name, namespace string) (string, error) {
+	_, sel, err := workloadObject(ctx, cs, resourceType, name, namespace)
+	if err != nil {
+		return "", err
+	}
+	return selectorString(resourceType, name, sel)
+}
+
+// workloadObject fetches a deployment/statefulset/daemonset and its pod selector.
+func workloadObject(ctx context.Context, cs *kubernetes.Clientset, resourceType, name, namespace string) (metav1.Object, *metav1.LabelSelector, error) {
+	switch strings.ToLower(resourceType) {
+	case "deployment":
+		d, err := cs.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s", formatK8sErr(err))
+		}
+		return d, d.Spec.Selector, nil
+	case "statefulset":
+		ss, err := cs.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s", formatK8sErr(err))
+		}
+		return ss, ss.Spec.Selector, nil
+	case "daemonset":
+		ds, err := cs.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s", formatK8sErr(err))
+		}
+		return ds, ds.Spec.Selector, nil
+	}
+	return nil, nil, fmt.Errorf("Error: resource type '%s' is not a supported workload (deployment, statefulset, daemonset)", resourceType)
+}
+
+func selectorString(resourceType, name string, sel *metav1.LabelSelector) (string, error) {
+	s, err := metav1.LabelSelectorAsSelector(sel)
+	if err != nil {
+		return "", fmt.Errorf("Error: invalid selector on %s/%s: %v", resourceType, name, err)
+	}
+	return s.String(), nil
+}
+
+func watchWorkload(ctx context.Context, cs *kubernetes.Clientset, resourceType, name, namespace string) (watch.Interface, error) {
+	opts := metav1.ListOptions{FieldSelector: "metadata.name=" + name}
+	switch strings.ToLower(resourceType) {
+	case "deployment":
+		return cs.AppsV1().Deployments(namespace).Watch(ctx, opts)
+	case "statefulset":
+		return cs.AppsV1().StatefulSets(namespace).Watch(ctx, opts)
+	case "daemonset":
+		return cs.AppsV1().DaemonSets(namespace).Watch(ctx, opts)
+	}
+	return nil, fmt.Errorf("resource type '%s' cannot be watched", resourceType)
+}
+
+// rolloutTree is the set of objects a workload controls: the workload, its
+// ReplicaSets and their pods (or its pods directly for StatefulSets and
+// DaemonSets), by UID. Events are matched against it the way k8s_diagnose
+// walks controller references, so "web" does not pick up "web-api".
+type rolloutTree struct {
+	cs        kubernetes.Interface
+	namespace string
+	owned     map[types.UID]bool
+	// checked holds UIDs already looked up, owned or not.
+	checked map[types.UID]bool
+}
+
+// newRolloutTree seeds the tree with the workload's current ReplicaSets and pods.
+func newRolloutTree(ctx context.Context, cs kubernetes.Interface, workload metav1.Object, selector string) (*rolloutTree, error) {
+	t := &rolloutTree{
+		cs:        cs,
+		namespace: workload.GetNamespace(),
+		owned:     map[types.UID]bool{workload.GetUID(): true},
+		checked:   map[types.UID]bool{},
+	}
+	opts := metav1.ListOptions{LabelSelector: selector}
+	if _, ok := workload.(*appsv1.Deployment); ok {
+		rss, err := cs.AppsV1().ReplicaSets(t.namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range rss.Items {
+			t.adopt(&rss.Items[i])
+		}
+	}
+	pods, err := cs.CoreV1().Pods(t.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		t.adopt(&pods.Items[i])
+	}
+	return t, nil
+}
+
+// adopt records obj if its controller is in the tree.
+func (t *rolloutTree) adopt(obj metav1.Object) bool {
+	if t.owned[obj.GetUID()] {
+		return true
+	}
+	if ref := metav1.GetControllerOfNoCopy(obj); ref != nil && t.owned[ref.UID] {
+		t.owned[obj.GetUID()] = true
+		return true
+	}
+	return false
+}
+
+// owns is adopt that also looks up a ReplicaSet controller created since the
+// tree was seeded (a new rollout revision).
+func (t *rolloutTree) owns(ctx context.Context, obj metav1.Object) bool {
+	if t.adopt(obj) {
+		return true
+	}
+	ref := metav1.GetControllerOfNoCopy(obj)
+	if ref == nil || ref.Kind != "ReplicaSet" || t.checked[ref.UID] {
+		return false
+	}
+	t.checked[ref.UID] = true
+	rs, err := t.cs.AppsV1().ReplicaSets(t.namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil || rs.UID != ref.UID || !t.adopt(rs) {
+		return false
+	}
+	return t.adopt(obj)
+}
+
+// relates reports whether e is about an object in the tree, looking up pods
+// and ReplicaSets it has not seen yet (their events can beat the pod watch).
+func (t *rolloutTree) relates(ctx context.Context, e *v1.Event) bool {
+	ref := e.InvolvedObject
+	if t.owned[ref.UID] {
+		return true
+	}
+	if ref.UID == "" || t.checked[ref.UID] {
+		return false
+	}
+	t.checked[ref.UID] = true
+	var obj metav1.Object
+	switch ref.Kind {
+	case "Pod":
+		p, err := t.cs.CoreV1().Pods(t.namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return false
+		}
+		obj = p
+	case "ReplicaSet":
+		rs, err := t.cs.AppsV1().ReplicaSets(t.namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return false
+		}
+		obj = rs
+	default:
+		return false
+	}
+	return obj.GetUID() == ref.UID && t.owns(ctx, obj)
+}
+
+func eventTimelineEntry(e *v1.Event) timelineEntry {
+	return timelineEntry{
+		Time:    eventTimestamp(e),
+		Source:  "event",
+		Object:  e.InvolvedObject.Kind + "/" + e.InvolvedObject.Name,
+		Message: fmt.Sprintf("%s %s: %s", e.Type, e.Reason, e.Message),
+	}
+}
+
+// podStateSummary is a one-line pod state, e.g. "Pending 0/1 ready; app: ImagePullBackOff".
+func podStateSummary(p *v1.Pod) string {
+	ready := 0
+	var notes []string
+	for _, c := range p.Status.ContainerStatuses {
+		if c.Ready {
+			ready++
+		}
+		switch {
+		case c.State.Waiting != nil && c.State.Waiting.Reason != "":
+			notes = append(notes, fmt.Sprintf("%s: %s", c.Name, c.State.Waiting.Reason))
+		case c.State.Terminated != nil:
+			notes = append(notes, fmt.Sprintf("%s: %s (exit %d)", c.Name, c.State.Terminated.Reason, c.State.Terminated.ExitCode))
+		}
+	}
+	s := fmt.Sprintf("%s %d/%d ready", p.Status.Phase, ready, len(p.Spec.Containers))
+	if p.DeletionTimestamp != nil {
+		s += " (terminating)"
+	}
+	if len(notes) > 0 {
+		s += "; " + strings.Join(notes, ", ")
+	}
+	return s
+}
+
+func nowRFC3339() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
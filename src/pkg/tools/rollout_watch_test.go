@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testMeta(name string, uid types.UID, owner metav1.Object, ownerKind string) metav1.ObjectMeta {
+	m := metav1.ObjectMeta{Name: name, Namespace: "default", UID: uid, Labels: map[string]string{"app": "web"}}
+	if owner != nil {
+		controller := true
+		m.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "apps/v1", Kind: ownerKind, Name: owner.GetName(), UID: owner.GetUID(), Controller: &controller,
+		}}
+	}
+	return m
+}
+
+func TestRolloutTreeMatchesByOwnership(t *testing.T) {
+	web := &appsv1.Deployment{ObjectMeta: testMeta("web", "dep-web", nil, "")}
+	webAPI := &appsv1.Deployment{ObjectMeta: testMeta("web-api", "dep-web-api", nil, "")}
+	rsWeb := &appsv1.ReplicaSet{ObjectMeta: testMeta("web-6d4b", "rs-web", web, "Deployment")}
+	rsAPI := &appsv1.ReplicaSet{ObjectMeta: testMeta("web-api-7f9c", "rs-web-api", webAPI, "Deployment")}
+	podWeb := &v1.Pod{ObjectMeta: testMeta("web-6d4b-abcde", "pod-web", rsWeb, "ReplicaSet")}
+	podAPI := &v1.Pod{ObjectMeta: testMeta("web-api-7f9c-fghij", "pod-web-api", rsAPI, "ReplicaSet")}
+	// Created after the tree was seeded: a new revision and its pod.
+	rsNew := &appsv1.ReplicaSet{ObjectMeta: testMeta("web-8e2a", "rs-web-new", web, "Deployment")}
+	podNew := &v1.Pod{ObjectMeta: testMeta("web-8e2a-klmno", "pod-web-new", rsNew, "ReplicaSet")}
+
+	cs := fake.NewSimpleClientset(web, webAPI, rsWeb, rsAPI, podWeb, podAPI)
+	ctx := context.Background()
+	tree, err := newRolloutTree(ctx, cs, web, "app=web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, obj := range []runtime.Object{rsNew, podNew} {
+		if err := cs.Tracker().Add(obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	event := func(kind string, obj metav1.Object) *v1.Event {
+		return &v1.Event{InvolvedObject: v1.ObjectReference{Kind: kind, Name: obj.GetName(), UID: obj.GetUID()}}
+	}
+	tests := []struct {
+		name string
+		e    *v1.Event
+		want bool
+	}{
+		{"the deployment", event("Deployment", web), true},
+		{"its replicaset", event("ReplicaSet", rsWeb), true},
+		{"its pod", event("Pod", podWeb), true},
+		{"a new replicaset", event("ReplicaSet", rsNew), true},
+		{"a new pod", event("Pod", podNew), true},
+		{"a name-prefixed deployment", event("Deployment", webAPI), false},
+		{"its replicaset", event("ReplicaSet", rsAPI), false},
+		{"its pod", event("Pod", podAPI), false},
+	}
+	for _, tt := range tests {
+		if got := tree.relates(ctx, tt.e); got != tt.want {
+			t.Errorf("%s (%s): relates=%v, want %v", tt.name, tt.e.InvolvedObject.Name, got, tt.want)
+		}
+	}
+}
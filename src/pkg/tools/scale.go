@@ -0,0 +1,216 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// K8sScale ports `kubectl scale` through the scale subresource, so it works for
+// any resource that exposes one (deployments, statefulsets, replicasets, CRDs).
+//
+// Args:
+// - resource_type, name, replicas required; namespace defaults to defaultNamespace()
+// - resource_version: optional precondition; fails with a Conflict if the object changed
+func K8sScale(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType, _ := args["resource_type"].(string)
+	name := getStringArg(args, "name", "resource_name")
+	namespace, _ := args["namespace"].(string)
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	replicas, ok := intFromArgs(args, "replicas")
+	if !ok {
+		return textErrorResult("replicas is required"), nil, nil
+	}
+	if replicas < 0 {
+		return textErrorResult("Error: replicas must be >= 0"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
+	}
+	if !hasSubresource(disc, gvr, "scale") {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' cannot be scaled (no scale subresource)", gvr.Resource)), nil, nil
+	}
+	if err := checkProtected(gvr, name, args, "scaling"); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	patch, _ := json.Marshal(map[string]any{"spec": map[string]any{"replicas": replicas}})
+	patch, err = withResourceVersion(patch, types.MergePatchType, getStringArg(args, "resource_version"))
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	var scale *unstructured.Unstructured
+	if namespaced {
+		scale, err = dyn.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "scale")
+	} else {
+		scale, err = dyn.Resource(gvr).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "scale")
+	}
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	current, _, _ := unstructured.NestedInt64(scale.Object, "status", "replicas")
+	return textOKResult(fmt.Sprintf("%s/%s scaled to %d replicas (currently %d)", gvr.Resource, name, replicas, current)), nil, nil
+}
+
+// savedReplicasAnnotation holds the replica count k8s_scale_zero scaled down from.
+const savedReplicasAnnotation = "mcp.merev/saved-replicas"
+
+// scalableWorkloads are the kinds k8s_scale_zero / k8s_scale_restore act on.
+var scalableWorkloads = []schema.GroupVersionResource{
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"},
+}
+
+type scaleZeroResult struct {
+	Resource string `json:"resource"`
+	Name     string `json:"name"`
+	Replicas int64  `json:"replicas"`
+	Status   string `json:"status"`
+	Message  string `json:"message,omitempty"`
+}
+
+// K8sScaleZero scales the deployments and statefulsets matching label_selector
+// to zero, recording each current replica count in the savedReplicasAnnotation
+// so k8s_scale_restore can bring them back ("pause this environment").
+//
+// Args: namespace defaults to defaultNamespace(); label_selector optional (all workloads if empty)
+func K8sScaleZero(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	return k8sScaleAll(ctx, args, false)
+}
+
+// K8sScaleRestore scales workloads paused by k8s_scale_zero back to their saved
+// replica count and removes the annotation. Args match K8sScaleZero.
+func K8sScaleRestore(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	return k8sScaleAll(ctx, args, true)
+}
+
+func k8sScaleAll(ctx context.Context, args map[string]any, restore bool) (*mcp.CallToolResult, any, error) {
+	namespace, _ := args["namespace"].(string)
+	selector := getStringArg(args, "label_selector", "selector")
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	results := []scaleZeroResult{}
+	for _, gvr := range scalableWorkloads {
+		if err := checkProtected(gvr, "("+selector+")", args, "scaling"); err != nil {
+			return textErrorResult(err.Error()), nil, nil
+		}
+		ri := dyn.Resource(gvr).Namespace(namespace)
+		list, err := ri.List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+			if !found {
+				replicas = 1 // API default
+			}
+			saved, hasSaved := obj.GetAnnotations()[savedReplicasAnnotation]
+
+			r := scaleZeroResult{Resource: gvr.Resource, Name: obj.GetName(), Replicas: replicas}
+			var annotations map[string]any
+			var target int64
+			switch {
+			case restore && !hasSaved:
+				continue
+			case restore:
+				n, err := strconv.ParseInt(saved, 10, 64)
+				if err != nil || n < 0 {
+					r.Status = "error"
+					r.Message = fmt.Sprintf("invalid %s annotation %q", savedReplicasAnnotation, saved)
+					results = append(results, r)
+					continue
+				}
+				target = n
+				annotations = map[string]any{savedReplicasAnnotation: nil}
+			case replicas == 0:
+				r.Status = "skipped"
+				r.Message = "already at 0 replicas"
+				results = append(results, r)
+				continue
+			default:
+				target = 0
+				annotations = map[string]any{savedReplicasAnnotation: strconv.FormatInt(replicas, 10)}
+			}
+
+			// Save the count first: if scaling fails the annotation is harmless,
+			// but scaling without it would lose the count.
+			if !restore {
+				if err := patchAnnotations(ctx, ri, obj.GetName(), annotations); err != nil {
+					r.Status = "error"
+					r.Message = formatK8sErr(err)
+					results = append(results, r)
+					continue
+				}
+			}
+
+			patch, _ := json.Marshal(map[string]any{"spec": map[string]any{"replicas": target}})
+			if _, err := ri.Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}, "scale"); err != nil {
+				r.Status = "error"
+				r.Message = formatK8sErr(err)
+				results = append(results, r)
+				continue
+			}
+
+			if restore {
+				if err := patchAnnotations(ctx, ri, obj.GetName(), annotations); err != nil {
+					r.Message = "scaled, but removing the annotation failed: " + formatK8sErr(err)
+				}
+			}
+			r.Replicas = target
+			r.Status = "scaled"
+			results = append(results, r)
+		}
+	}
+
+	b, _ := json.MarshalIndent(map[string]any{
+		"namespace": namespace,
+		"workloads": results,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// patchAnnotations merge-patches metadata.annotations; nil values remove keys.
+func patchAnnotations(ctx context.Context, ri dynamic.ResourceInterface, name string, annotations map[string]any) error {
+	patch, _ := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": annotations}})
+	_, err := ri.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
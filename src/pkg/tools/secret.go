@@ -0,0 +1,169 @@
+package tools
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// K8sCreateSecret builds a typed Secret from plain inputs, doing the base64 and
+// dockerconfigjson encoding that is easy to get wrong in hand-written YAML.
+//
+// Args:
+// - name (required); namespace defaults to defaultNamespace()
+// - type: "opaque" (default, alias "generic"), "docker-registry" or "tls" (or the kubernetes.io/... name)
+// - data: object of key -> plain string value
+// - from_literals: list or comma-separated "key=value" entries (like --from-literal)
+// - docker_config: {server, username, password, email} for docker-registry
+// - for tls, data must hold tls.crt and tls.key (PEM); tls_cert/tls_key are shortcuts
+//
+// The result lists keys and sizes only; values are never echoed back.
+func K8sCreateSecret(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	name := getStringArg(args, "name")
+	namespace := getStringArg(args, "namespace")
+	secretType := getStringArg(args, "type")
+
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	data := map[string][]byte{}
+	if raw, ok := args["data"]; ok && raw != nil {
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return textErrorResult("Error: data must be an object of key -> value"), nil, nil
+		}
+		for k, v := range m {
+			data[k] = []byte(fmtAny(v))
+		}
+	}
+	for _, lit := range stringSliceFromArgs(args, "from_literals") {
+		k, v, ok := strings.Cut(lit, "=")
+		if !ok || k == "" {
+			return textErrorResult(fmt.Sprintf("Error: invalid literal %q (expected key=value)", lit)), nil, nil
+		}
+		if _, dup := data[k]; dup {
+			return textErrorResult(fmt.Sprintf("Error: key '%s' is given more than once", k)), nil, nil
+		}
+		data[k] = []byte(v)
+	}
+
+	var st v1.SecretType
+	switch strings.ToLower(secretType) {
+	case "", "opaque", "generic":
+		st = v1.SecretTypeOpaque
+	case "docker-registry", "dockerconfigjson", strings.ToLower(string(v1.SecretTypeDockerConfigJson)):
+		st = v1.SecretTypeDockerConfigJson
+		cfg, err := dockerConfigJSON(args["docker_config"])
+		if err != nil {
+			return textErrorResult("Error: " + err.Error()), nil, nil
+		}
+		data[v1.DockerConfigJsonKey] = cfg
+	case "tls", strings.ToLower(string(v1.SecretTypeTLS)):
+		st = v1.SecretTypeTLS
+		if c := getStringArg(args, "tls_cert"); c != "" {
+			data[v1.TLSCertKey] = []byte(c)
+		}
+		if k := getStringArg(args, "tls_key"); k != "" {
+			data[v1.TLSPrivateKeyKey] = []byte(k)
+		}
+		if len(data[v1.TLSCertKey]) == 0 || len(data[v1.TLSPrivateKeyKey]) == 0 {
+			return textErrorResult("Error: tls secrets need tls.crt and tls.key (or tls_cert and tls_key)"), nil, nil
+		}
+		if _, err := tls.X509KeyPair(data[v1.TLSCertKey], data[v1.TLSPrivateKeyKey]); err != nil {
+			return textErrorResult("Error: invalid TLS key pair: " + err.Error()), nil, nil
+		}
+	default:
+		return textErrorResult(fmt.Sprintf("Error: unsupported type '%s' (expected opaque, docker-registry or tls)", secretType)), nil, nil
+	}
+
+	if len(data) == 0 {
+		return textErrorResult("data or from_literals is required"), nil, nil
+	}
+	if err := validateDataKeys(data); err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       st,
+		Data:       data,
+	}
+	created, err := cs.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	keys := map[string]string{}
+	for k, v := range created.Data {
+		keys[k] = fmt.Sprintf("<redacted, %d bytes>", len(v))
+	}
+	b, _ := json.MarshalIndent(map[string]any{
+		"name":             created.Name,
+		"namespace":        created.Namespace,
+		"type":             string(created.Type),
+		"data":             keys,
+		"uid":              string(created.UID),
+		"resource_version": created.ResourceVersion,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// dockerConfigJSON renders {server, username, password, email} as the
+// .dockerconfigjson document kubelet expects, auth included.
+func dockerConfigJSON(v any) ([]byte, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("docker_config {server, username, password} is required for docker-registry secrets")
+	}
+	server := getStringArg(m, "server")
+	username := getStringArg(m, "username")
+	password := getStringArg(m, "password")
+	email := getStringArg(m, "email")
+	if server == "" || username == "" || password == "" {
+		return nil, fmt.Errorf("docker_config needs server, username and password")
+	}
+
+	entry := map[string]string{
+		"username": username,
+		"password": password,
+		"auth":     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+	}
+	if email != "" {
+		entry["email"] = email
+	}
+	return json.Marshal(map[string]any{"auths": map[string]any{server: entry}})
+}
+
+// validateDataKeys applies the ConfigMap/Secret key rules (alphanumerics, '-',
+// '_' and '.') and reports every bad key at once.
+func validateDataKeys[V any](data map[string]V) error {
+	var problems []string
+	for k := range data {
+		if errs := validation.IsConfigMapKey(k); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("'%s': %s", k, strings.Join(errs, "; ")))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("invalid keys: %s", strings.Join(problems, ", "))
+}
@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// K8sServiceLogs shows the logs of the pods behind a Service: the pods its
+// selector matches or, for a Service without a selector (manually managed
+// endpoints), the pods its EndpointSlices point at. Headless services resolve
+// the same way. Logs are aggregated with one section per pod.
+//
+// Args:
+// - name (required); namespace defaults to defaultNamespace()
+// - tail (default 100 per pod), since, timestamps, previous as in k8s_logs
+// - container: defaults to each pod's default container
+// - max_pods: default 10; ready pods are preferred
+func K8sServiceLogs(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	name := getStringArg(args, "name", "service")
+	namespace := getStringArg(args, "namespace")
+	container := getStringArg(args, "container")
+	maxPods := intFromArgsDefault(args, "max_pods", 10)
+
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	tailLines, sinceSeconds, err := logWindowFromArgs(args)
+	if err != nil {
+		return textErrorResult("Error: " + err.Error()), nil, nil
+	}
+	if _, set := args["tail"]; !set {
+		tail := int64(100)
+		tailLines = &tail
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	svc, err := cs.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	if svc.Spec.Type == v1.ServiceTypeExternalName {
+		return textErrorResult(fmt.Sprintf("Error: service %s/%s is ExternalName (%s) and has no pods", namespace, name, svc.Spec.ExternalName)), nil, nil
+	}
+
+	pods, how, err := servicePods(ctx, cs, svc)
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+	if len(pods) == 0 {
+		return textOKResult(fmt.Sprintf("No pods back service %s/%s (%s)", namespace, name, how)), nil, nil
+	}
+
+	// Ready pods first, then by name, so max_pods keeps the serving ones.
+	sort.SliceStable(pods, func(i, j int) bool {
+		ri, rj := podReady(&pods[i]), podReady(&pods[j])
+		if ri != rj {
+			return ri
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	header := fmt.Sprintf("Service %s/%s: %d pod(s) via %s", namespace, name, len(pods), how)
+	if maxPods > 0 && len(pods) > maxPods {
+		header += fmt.Sprintf(", showing %d (max_pods)", maxPods)
+		pods = pods[:maxPods]
+	}
+
+	targets := make([]podLogTarget, 0, len(pods))
+	for i := range pods {
+		c := container
+		if c == "" {
+			c = podDefaultContainerName(&pods[i])
+		}
+		targets = append(targets, podLogTarget{pod: &pods[i], container: c})
+	}
+	logs := aggregatePodLogs(ctx, cs, targets, v1.PodLogOptions{
+		Previous:     boolFromArgs(args, "previous", false),
+		Timestamps:   boolFromArgs(args, "timestamps", false),
+		TailLines:    tailLines,
+		SinceSeconds: sinceSeconds,
+	})
+	return textOKResult(header + "\n\n" + logs), nil, nil
+}
+
+// servicePods resolves the pods behind svc and says how they were found.
+func servicePods(ctx context.Context, cs *kubernetes.Clientset, svc *v1.Service) ([]v1.Pod, string, error) {
+	if len(svc.Spec.Selector) > 0 {
+		sel := labels.SelectorFromSet(svc.Spec.Selector).String()
+		list, err := cs.CoreV1().Pods(svc.Namespace).List(ctx, metav1.ListOptions{LabelSelector: sel})
+		if err != nil {
+			return nil, "", err
+		}
+		pods := make([]v1.Pod, 0, len(list.Items))
+		for _, p := range list.Items {
+			if !isCompletedPod(&p) {
+				pods = append(pods, p)
+			}
+		}
+		return pods, "selector " + sel, nil
+	}
+
+	slices, err := cs.DiscoveryV1().EndpointSlices(svc.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + svc.Name,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	seen := map[string]bool{}
+	var pods []v1.Pod
+	for _, s := range slices.Items {
+		for _, e := range s.Endpoints {
+			if e.TargetRef == nil || e.TargetRef.Kind != "Pod" || seen[e.TargetRef.Name] {
+				continue
+			}
+			seen[e.TargetRef.Name] = true
+			p, err := cs.CoreV1().Pods(svc.Namespace).Get(ctx, e.TargetRef.Name, metav1.GetOptions{})
+			if err != nil {
+				continue
+			}
+			pods = append(pods, *p)
+		}
+	}
+	return pods, "endpointslices (service has no selector)", nil
+}
+
+func podReady(pod *v1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -3,15 +3,23 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
 )
 
 // K8sSetResources ports k8s_set_resources(...)
+// It returns a summary of the changed containers and their new requests/limits;
+// include_object (bool) adds the full updated object.
 func K8sSetResources(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	resourceType, _ := args["resource_type"].(string)
 	resourceName, _ := args["resource_name"].(string)
@@ -24,13 +32,21 @@ func K8sSetResources(ctx context.Context, _ *mcp.CallToolRequest, args map[strin
 		return textErrorResult("resource_name is required"), nil, nil
 	}
 	if namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	containers := stringSliceFromArgs(args, "containers")
 
 	limits, _ := args["limits"].(map[string]any)
 	requests, _ := args["requests"].(map[string]any)
+	includeObject := boolFromArgs(args, "include_object", false)
+
+	// Check quantities here: the API server's rejection doesn't say which one is wrong.
+	for field, m := range map[string]map[string]any{"limits": limits, "requests": requests} {
+		if err := normalizeQuantities(field, m); err != nil {
+			return textErrorResult("Error: " + err.Error()), nil, nil
+		}
+	}
 
 	disc, err := getDiscovery()
 	if err != nil {
@@ -49,6 +65,9 @@ func K8sSetResources(ctx context.Context, _ *mcp.CallToolRequest, args map[strin
 	if !found {
 		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
 	}
+	if err := checkProtected(gvr, resourceName, args, "setting resources on"); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
 
 	ri := dyn.Resource(gvr)
 	var obj *unstructured.Unstructured
@@ -90,51 +109,128 @@ func K8sSetResources(ctx context.Context, _ *mcp.CallToolRequest, args map[strin
 		}
 	}
 
-	if err := updateContainers(obj.Object, containersPath, func(c map[string]any) error {
-		if len(containers) > 0 {
-			if !stringInSlice(fmtAny(c["name"]), containers) {
-				return nil
+	var changed []containerResources
+	apply := func(o *unstructured.Unstructured) error {
+		changed = []containerResources{}
+		if err := updateContainers(o.Object, containersPath, func(c map[string]any) error {
+			if len(containers) > 0 {
+				if !stringInSlice(fmtAny(c["name"]), containers) {
+					return nil
+				}
 			}
-		}
 
-		res, _ := c["resources"].(map[string]any)
-		if res == nil {
-			res = map[string]any{}
-			c["resources"] = res
-		}
+			res, _ := c["resources"].(map[string]any)
+			if res == nil {
+				res = map[string]any{}
+				c["resources"] = res
+			}
 
-		if limits != nil {
-			res["limits"] = limits
+			if limits != nil {
+				res["limits"] = limits
+			}
+			if requests != nil {
+				res["requests"] = requests
+			}
+			cr := containerResources{Name: fmtAny(c["name"])}
+			cr.Requests, _ = res["requests"].(map[string]any)
+			cr.Limits, _ = res["limits"].(map[string]any)
+			if err := checkRequestsWithinLimits(cr); err != nil {
+				return err
+			}
+			changed = append(changed, cr)
+			return nil
+		}); err != nil {
+			return errors.New("Error:\n" + err.Error())
 		}
-		if requests != nil {
-			res["requests"] = requests
+		if len(changed) == 0 {
+			return fmt.Errorf("Error: no container in '%s/%s' matches %s", resourceType, resourceName, strings.Join(containers, ", "))
 		}
 		return nil
-	}); err != nil {
-		return textErrorResult("Error:\n" + err.Error()), nil, nil
+	}
+	if err := apply(obj); err != nil {
+		return textErrorResult(err.Error()), nil, nil
 	}
 
-	// Update (replace) resource like python rc.replace(...)
-	var updated *unstructured.Unstructured
+	var rif dynamic.ResourceInterface = ri
 	if namespaced {
-		u, err := ri.Namespace(namespace).Update(ctx, obj, metav1.UpdateOptions{})
-		if err != nil {
-			return textErrorResult(formatK8sErr(err)), nil, nil
+		rif = ri.Namespace(namespace)
+	}
+	updated, err := updateWithConflictRetry(ctx, rif, obj, getStringArg(args, "resource_version"), apply)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	out := map[string]any{
+		"kind":             updated.GetKind(),
+		"name":             updated.GetName(),
+		"namespace":        updated.GetNamespace(),
+		"resource_version": updated.GetResourceVersion(),
+		"containers":       changed,
+	}
+	if includeObject {
+		out["object"] = updated.Object
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// containerResources is one changed container in the k8s_set_resources summary.
+type containerResources struct {
+	Name     string         `json:"name"`
+	Requests map[string]any `json:"requests,omitempty"`
+	Limits   map[string]any `json:"limits,omitempty"`
+}
+
+// normalizeQuantities checks every value of a limits/requests map with
+// resource.ParseQuantity, naming the offending key, and turns JSON numbers
+// into their string form.
+func normalizeQuantities(field string, m map[string]any) error {
+	for k, v := range m {
+		var s string
+		switch t := v.(type) {
+		case string:
+			s = strings.TrimSpace(t)
+		case float64:
+			s = strconv.FormatFloat(t, 'f', -1, 64)
+		default:
+			return fmt.Errorf("%s.%s: quantity must be a string like \"500m\" or \"256Mi\", got %v", field, k, v)
 		}
-		updated = u
-	} else {
-		u, err := ri.Update(ctx, obj, metav1.UpdateOptions{})
+		q, err := resource.ParseQuantity(s)
 		if err != nil {
-			return textErrorResult(formatK8sErr(err)), nil, nil
+			return fmt.Errorf("%s.%s: invalid quantity %q (expected e.g. \"500m\", \"2\", \"256Mi\")", field, k, s)
 		}
-		updated = u
+		if q.Sign() < 0 {
+			return fmt.Errorf("%s.%s: quantity %q must not be negative", field, k, s)
+		}
+		m[k] = s
 	}
+	return nil
+}
 
-	b, _ := json.MarshalIndent(updated.Object, "", "  ")
-	return textOKResult(string(b)), nil, nil
+// checkRequestsWithinLimits reports a request above its limit, which the API
+// server rejects. Either side may come from the container's existing values.
+func checkRequestsWithinLimits(cr containerResources) error {
+	for k, lv := range cr.Limits {
+		rv, ok := cr.Requests[k]
+		if !ok {
+			continue
+		}
+		limit, err1 := resource.ParseQuantity(fmtAny(lv))
+		request, err2 := resource.ParseQuantity(fmtAny(rv))
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		if request.Cmp(limit) > 0 {
+			return fmt.Errorf("container '%s': requests.%s (%s) must not exceed limits.%s (%s)", cr.Name, k, request.String(), k, limit.String())
+		}
+	}
+	return nil
 }
 
 // K8sSetImage ports k8s_set_image(resource_type, resource_name, container, image, namespace)
+// It returns a "changed: container X image old -> new" summary, whether the
+// change triggers a rollout, and the updated object. An image that is already
+// set is reported as unchanged and nothing is written.
 func K8sSetImage(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	resourceType, _ := args["resource_type"].(string)
 	resourceName, _ := args["resource_name"].(string)
@@ -155,7 +251,7 @@ func K8sSetImage(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 		return textErrorResult("image is required"), nil, nil
 	}
 	if namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	disc, err := getDiscovery()
@@ -174,6 +270,9 @@ func K8sSetImage(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 	if !found {
 		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
 	}
+	if err := checkProtected(gvr, resourceName, args, "setting the image of"); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
 
 	ri := dyn.Resource(gvr)
 
@@ -214,41 +313,95 @@ func K8sSetImage(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 		}
 	}
 
-	containerFound := false
-	if err := updateContainers(obj.Object, containersPath, func(c map[string]any) error {
-		if fmtAny(c["name"]) != containerName {
+	var oldImage string
+	apply := func(o *unstructured.Unstructured) error {
+		containerFound := false
+		if err := updateContainers(o.Object, containersPath, func(c map[string]any) error {
+			if fmtAny(c["name"]) != containerName {
+				return nil
+			}
+			oldImage = fmtAny(c["image"])
+			c["image"] = image
+			containerFound = true
 			return nil
+		}); err != nil {
+			return errors.New("Error:\n" + err.Error())
+		}
+
+		if !containerFound {
+			return fmt.Errorf("Error: container '%s' not found in resource '%s/%s'", containerName, resourceType, resourceName)
 		}
-		c["image"] = image
-		containerFound = true
 		return nil
-	}); err != nil {
-		return textErrorResult("Error:\n" + err.Error()), nil, nil
+	}
+	if err := apply(obj); err != nil {
+		return textErrorResult(err.Error()), nil, nil
 	}
 
-	if !containerFound {
-		return textErrorResult(fmt.Sprintf("Error: container '%s' not found in resource '%s/%s'", containerName, resourceType, resourceName)), nil, nil
+	out := map[string]any{
+		"kind":      obj.GetKind(),
+		"name":      obj.GetName(),
+		"namespace": obj.GetNamespace(),
+		"container": containerName,
+		"old_image": oldImage,
+		"new_image": image,
+	}
+	if oldImage == image {
+		// Nothing would change; skip the update so no rollout is triggered.
+		out["changed"] = false
+		out["summary"] = fmt.Sprintf("unchanged: container %s already runs %s", containerName, image)
+		out["triggers_rollout"] = false
+		b, _ := json.MarshalIndent(out, "", "  ")
+		return textOKResult(string(b)), nil, nil
 	}
 
-	var updated *unstructured.Unstructured
+	var rif dynamic.ResourceInterface = ri
 	if namespaced {
-		u, err := ri.Namespace(namespace).Update(ctx, obj, metav1.UpdateOptions{})
-		if err != nil {
-			return textErrorResult(formatK8sErr(err)), nil, nil
-		}
-		updated = u
-	} else {
-		u, err := ri.Update(ctx, obj, metav1.UpdateOptions{})
-		if err != nil {
-			return textErrorResult(formatK8sErr(err)), nil, nil
-		}
-		updated = u
+		rif = ri.Namespace(namespace)
+	}
+	updated, err := updateWithConflictRetry(ctx, rif, obj, getStringArg(args, "resource_version"), apply)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
 	}
 
-	b, _ := json.MarshalIndent(updated.Object, "", "  ")
+	effect, rollout := imageChangeEffect(updated)
+	out["changed"] = true
+	out["summary"] = fmt.Sprintf("changed: container %s image %s -> %s", containerName, oldImage, image)
+	out["old_image"] = oldImage
+	out["triggers_rollout"] = rollout
+	out["effect"] = effect
+	out["resource_version"] = updated.GetResourceVersion()
+	out["object"] = updated.Object
+	b, _ := json.MarshalIndent(out, "", "  ")
 	return textOKResult(string(b)), nil, nil
 }
 
+// imageChangeEffect says what a changed pod template image does to running
+// pods of obj, and whether that is a rollout.
+func imageChangeEffect(obj *unstructured.Unstructured) (string, bool) {
+	switch strings.ToLower(obj.GetKind()) {
+	case "deployment":
+		if paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused"); paused {
+			return "deployment is paused; the rollout starts when it is resumed", false
+		}
+		return "a new rollout replaces the pods", true
+	case "statefulset":
+		if s, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type"); s == "OnDelete" {
+			return "updateStrategy is OnDelete; pods get the image only when deleted", false
+		}
+		return "a new rollout replaces the pods", true
+	case "daemonset":
+		if s, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type"); s == "OnDelete" {
+			return "updateStrategy is OnDelete; pods get the image only when deleted", false
+		}
+		return "a new rollout replaces the pods", true
+	case "replicaset":
+		return "only pods created from now on use the image; existing pods keep the old one", false
+	case "pod":
+		return "the container is restarted in place with the new image", false
+	}
+	return "", false
+}
+
 // K8sSetEnv ports k8s_set_env(resource_type, resource_name, container, env_dict, namespace)
 func K8sSetEnv(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	resourceType, _ := args["resource_type"].(string)
@@ -271,7 +424,7 @@ func K8sSetEnv(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any)
 		return textErrorResult("env_dict is required (object/map)"), nil, nil
 	}
 	if namespace == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	disc, err := getDiscovery()
@@ -290,6 +443,9 @@ func K8sSetEnv(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any)
 	if !found {
 		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
 	}
+	if err := checkProtected(gvr, resourceName, args, "setting env on"); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
 
 	ri := dyn.Resource(gvr)
 
@@ -330,70 +486,70 @@ func K8sSetEnv(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any)
 		}
 	}
 
-	containerFound := false
-	if err := updateContainers(obj.Object, containersPath, func(c map[string]any) error {
-		if fmtAny(c["name"]) != containerName {
-			return nil
-		}
-
-		// Ensure env exists as []any
-		envAny, ok := c["env"].([]any)
-		if !ok || envAny == nil {
-			envAny = []any{}
-		}
-
-		// Index existing by name
-		index := map[string]int{}
-		for i := range envAny {
-			m, _ := envAny[i].(map[string]any)
-			if m == nil {
-				continue
+	apply := func(o *unstructured.Unstructured) error {
+		containerFound := false
+		if err := updateContainers(o.Object, containersPath, func(c map[string]any) error {
+			if fmtAny(c["name"]) != containerName {
+				return nil
 			}
-			n := fmtAny(m["name"])
-			if n != "" {
-				index[n] = i
+
+			// Ensure env exists as []any
+			envAny, ok := c["env"].([]any)
+			if !ok || envAny == nil {
+				envAny = []any{}
 			}
-		}
 
-		for k, v := range envDict {
-			val := fmtAny(v)
-			if i, exists := index[k]; exists {
+			// Index existing by name
+			index := map[string]int{}
+			for i := range envAny {
 				m, _ := envAny[i].(map[string]any)
 				if m == nil {
-					m = map[string]any{}
+					continue
+				}
+				n := fmtAny(m["name"])
+				if n != "" {
+					index[n] = i
 				}
-				m["name"] = k
-				m["value"] = val
-				envAny[i] = m
-			} else {
-				envAny = append(envAny, map[string]any{"name": k, "value": val})
 			}
+
+			for k, v := range envDict {
+				val := fmtAny(v)
+				if i, exists := index[k]; exists {
+					m, _ := envAny[i].(map[string]any)
+					if m == nil {
+						m = map[string]any{}
+					}
+					m["name"] = k
+					m["value"] = val
+					envAny[i] = m
+				} else {
+					envAny = append(envAny, map[string]any{"name": k, "value": val})
+				}
+			}
+
+			c["env"] = envAny
+			containerFound = true
+			return nil
+		}); err != nil {
+			return errors.New("Error:\n" + err.Error())
 		}
 
-		c["env"] = envAny
-		containerFound = true
+		if !containerFound {
+			return fmt.Errorf("Error: container '%s' not found in resource '%s/%s'", containerName, resourceType, resourceName)
+		}
 		return nil
-	}); err != nil {
-		return textErrorResult("Error:\n" + err.Error()), nil, nil
 	}
-
-	if !containerFound {
-		return textErrorResult(fmt.Sprintf("Error: container '%s' not found in resource '%s/%s'", containerName, resourceType, resourceName)), nil, nil
+	if err := apply(obj); err != nil {
+		return textErrorResult(err.Error()), nil, nil
 	}
 
-	var updated *unstructured.Unstructured
+	var rif dynamic.ResourceInterface = ri
 	if namespaced {
-		u, err := ri.Namespace(namespace).Update(ctx, obj, metav1.UpdateOptions{})
-		if err != nil {
-			return textErrorResult(formatK8sErr(err)), nil, nil
-		}
-		updated = u
-	} else {
-		u, err := ri.Update(ctx, obj, metav1.UpdateOptions{})
-		if err != nil {
-			return textErrorResult(formatK8sErr(err)), nil, nil
-		}
-		updated = u
+		rif = ri.Namespace(namespace)
+	}
+	updated, err := updateWithConflictRetry(ctx, rif, obj, getStringArg(args, "resource_version"), apply)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
 	}
 
 	b, _ := json.MarshalIndent(updated.Object, "", "  ")
@@ -402,6 +558,124 @@ func K8sSetEnv(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any)
 
 // ---- helpers ----
 
+// setTarget is a workload fetched for a `kubectl set`-style edit of its pod spec.
+type setTarget struct {
+	ri          dynamic.ResourceInterface
+	obj         *unstructured.Unstructured
+	podSpecPath []string
+}
+
+// loadSetTarget fetches resourceType/name and locates its pod spec. what names
+// the edit ("setting probes", ...) for the unsupported-type error and for the
+// protected-kinds guard, which reads confirm from args. Errors are ready to
+// show to the user.
+func loadSetTarget(ctx context.Context, args map[string]any, resourceType, name, namespace, what string) (*setTarget, error) {
+	disc, err := getDiscovery()
+	if err != nil {
+		return nil, err
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return nil, err
+	}
+
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		gvr, namespaced, found = findGVR(disc, resourceType+"s")
+	}
+	if !found {
+		return nil, fmt.Errorf("Error: resource '%s' not found in cluster", resourceType)
+	}
+	if err := checkProtected(gvr, name, args, what+" on"); err != nil {
+		return nil, err
+	}
+
+	var ri dynamic.ResourceInterface = dyn.Resource(gvr)
+	if namespaced {
+		ri = dyn.Resource(gvr).Namespace(namespace)
+	}
+	obj, err := ri.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.New(formatK8sErr(err))
+	}
+
+	podSpecPath := podSpecPathForKind(obj.GetKind())
+	if podSpecPath == nil {
+		return nil, fmt.Errorf("Error: resource type '%s' does not support %s", resourceType, what)
+	}
+	return &setTarget{ri: ri, obj: obj, podSpecPath: podSpecPath}, nil
+}
+
+// podSpecPathForKind is where a built-in workload kind keeps its pod spec, or
+// nil for kinds without one.
+func podSpecPathForKind(kind string) []string {
+	switch strings.ToLower(kind) {
+	case "deployment", "statefulset", "daemonset", "replicaset", "job":
+		return []string{"spec", "template", "spec"}
+	case "cronjob":
+		return []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	case "pod":
+		return []string{"spec"}
+	}
+	return nil
+}
+
+func (t *setTarget) containersPath() []string {
+	return append(append([]string{}, t.podSpecPath...), "containers")
+}
+
+// update writes the edited object back; see updateWithConflictRetry for how
+// resourceVersion and mutate are used.
+func (t *setTarget) update(ctx context.Context, resourceVersion string, mutate func(*unstructured.Unstructured) error) (*unstructured.Unstructured, error) {
+	return updateWithConflictRetry(ctx, t.ri, t.obj, resourceVersion, mutate)
+}
+
+// updateWithConflictRetry writes obj, already edited by mutate, back with
+// Update. When that fails with a Conflict because a controller wrote the
+// object in between, the live object is re-read, mutate applied to it again
+// and the update retried, up to --conflict-retries times. A non-empty
+// resourceVersion asks for optimistic concurrency instead: the update is
+// pinned to it and a Conflict is returned as is. Errors are ready to show to
+// the user.
+func updateWithConflictRetry(ctx context.Context, ri dynamic.ResourceInterface, obj *unstructured.Unstructured, resourceVersion string, mutate func(*unstructured.Unstructured) error) (*unstructured.Unstructured, error) {
+	if resourceVersion != "" {
+		obj.SetResourceVersion(resourceVersion)
+		u, err := ri.Update(ctx, obj, metav1.UpdateOptions{})
+		if err != nil {
+			return nil, errors.New(formatK8sErr(err))
+		}
+		return u, nil
+	}
+
+	var updated *unstructured.Unstructured
+	var mutateErr error
+	attempt := 0
+	err := retry.RetryOnConflict(conflictBackoff(), func() error {
+		if attempt++; attempt > 1 {
+			live, err := ri.Get(ctx, obj.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if mutateErr = mutate(live); mutateErr != nil {
+				return mutateErr
+			}
+			obj = live
+		}
+		u, err := ri.Update(ctx, obj, metav1.UpdateOptions{})
+		updated = u
+		return err
+	})
+	switch {
+	case mutateErr != nil:
+		return nil, mutateErr
+	case apierrors.IsConflict(err):
+		return nil, fmt.Errorf("Error:\nConflict: %v\nThe object kept changing; gave up after %d attempt(s).", err, attempt)
+	case err != nil:
+		return nil, errors.New(formatK8sErr(err))
+	}
+	return updated, nil
+}
+
 func updateContainers(root map[string]any, containersPath []string, fn func(container map[string]any) error) error {
 	containersAny, found, err := unstructured.NestedSlice(root, containersPath...)
 	if err != nil {
@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// K8sSetCommand sets or clears a container's command and/or args in a
+// workload's pod template (or a pod), e.g. to add a debug flag, without
+// addressing the nested arrays in a raw patch.
+//
+// Args:
+// - resource_type, name (or resource_name) (required); namespace defaults to defaultNamespace()
+// - container: required when the pod template has more than one container
+// - command, args: lists of strings; null clears the field so the image's
+// ENTRYPOINT/CMD apply again, a missing key leaves it as is (at least one is required)
+// - resource_version: optional optimistic-concurrency guard
+func K8sSetCommand(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType := getStringArg(args, "resource_type")
+	name := getStringArg(args, "name", "resource_name")
+	namespace := getStringArg(args, "namespace")
+	containerName := getStringArg(args, "container")
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	// nil value = clear the field.
+	changes := map[string][]any{}
+	for _, field := range []string{"command", "args"} {
+		raw, ok := args[field]
+		if !ok {
+			continue
+		}
+		if raw == nil {
+			changes[field] = nil
+			continue
+		}
+		list, ok := raw.([]any)
+		if !ok {
+			return textErrorResult(fmt.Sprintf("Error: %s must be a list of strings or null", field)), nil, nil
+		}
+		for i, v := range list {
+			if _, ok := v.(string); !ok {
+				return textErrorResult(fmt.Sprintf("Error: %s[%d] must be a string", field, i)), nil, nil
+			}
+		}
+		changes[field] = list
+	}
+	if len(changes) == 0 {
+		return textErrorResult("command or args is required (a list of strings, or null to clear)"), nil, nil
+	}
+
+	t, err := loadSetTarget(ctx, args, resourceType, name, namespace, "setting command/args")
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	containerName, err = resolveSetContainer(t.obj, t.containersPath(), containerName)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	var result map[string]any
+	apply := func(o *unstructured.Unstructured) error {
+		if err := updateContainers(o.Object, t.containersPath(), func(c map[string]any) error {
+			if fmtAny(c["name"]) != containerName {
+				return nil
+			}
+			for field, list := range changes {
+				if len(list) == 0 {
+					delete(c, field)
+				} else {
+					c[field] = list
+				}
+			}
+			result = map[string]any{"command": c["command"], "args": c["args"]}
+			return nil
+		}); err != nil {
+			return errors.New("Error:\n" + err.Error())
+		}
+		return nil
+	}
+	if err := apply(t.obj); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	updated, err := t.update(ctx, getStringArg(args, "resource_version"), apply)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	out := map[string]any{
+		"kind":             updated.GetKind(),
+		"name":             updated.GetName(),
+		"namespace":        updated.GetNamespace(),
+		"container":        containerName,
+		"resource_version": updated.GetResourceVersion(),
+	}
+	for _, field := range []string{"command", "args"} {
+		if v := result[field]; v != nil {
+			out[field] = v
+		} else {
+			out[field] = "<image default>"
+		}
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
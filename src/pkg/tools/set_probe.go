@@ -0,0 +1,174 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// K8sSetProbe sets or removes one probe on a container of a workload's pod
+// template (or a pod), like editing livenessProbe by hand without having to
+// address the container array in a raw patch.
+//
+// Args:
+// - resource_type, name (or resource_name) (required); namespace defaults to defaultNamespace()
+// - container: required when the pod template has more than one container
+// - probe_type: liveness, readiness or startup (required)
+// - probe_spec: a core/v1 Probe object (exec/httpGet/tcpSocket/grpc plus timings);
+// null or missing removes the probe
+// - resource_version: optional optimistic-concurrency guard
+func K8sSetProbe(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType := getStringArg(args, "resource_type")
+	name := getStringArg(args, "name", "resource_name")
+	namespace := getStringArg(args, "namespace")
+	containerName := getStringArg(args, "container")
+	probeType := strings.ToLower(getStringArg(args, "probe_type"))
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	field := map[string]string{
+		"liveness":  "livenessProbe",
+		"readiness": "readinessProbe",
+		"startup":   "startupProbe",
+	}[strings.TrimSuffix(probeType, "probe")]
+	if field == "" {
+		return textErrorResult("Error: probe_type must be liveness, readiness or startup"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	var probe map[string]any
+	if raw, ok := args["probe_spec"]; ok && raw != nil {
+		p, err := validateProbe(raw)
+		if err != nil {
+			return textErrorResult("Error: invalid probe_spec: " + err.Error()), nil, nil
+		}
+		probe = p
+	}
+
+	t, err := loadSetTarget(ctx, args, resourceType, name, namespace, "setting probes")
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	containerName, err = resolveSetContainer(t.obj, t.containersPath(), containerName)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	apply := func(o *unstructured.Unstructured) error {
+		if err := updateContainers(o.Object, t.containersPath(), func(c map[string]any) error {
+			if fmtAny(c["name"]) != containerName {
+				return nil
+			}
+			if probe == nil {
+				delete(c, field)
+			} else {
+				c[field] = probe
+			}
+			return nil
+		}); err != nil {
+			return errors.New("Error:\n" + err.Error())
+		}
+		return nil
+	}
+	if err := apply(t.obj); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	updated, err := t.update(ctx, getStringArg(args, "resource_version"), apply)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	action := "set"
+	if probe == nil {
+		action = "removed"
+	}
+	out := map[string]any{
+		"kind":             updated.GetKind(),
+		"name":             updated.GetName(),
+		"namespace":        updated.GetNamespace(),
+		"container":        containerName,
+		"probe":            field,
+		"action":           action,
+		"resource_version": updated.GetResourceVersion(),
+	}
+	if probe != nil {
+		out["spec"] = probe
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// validateProbe decodes raw strictly into a v1.Probe (so misspelled fields are
+// rejected rather than silently dropped by the API server) and requires
+// exactly one handler. It returns the probe as an unstructured map.
+func validateProbe(raw any) (map[string]any, error) {
+	if s, ok := raw.(string); ok {
+		var m map[string]any
+		if err := json.Unmarshal([]byte(s), &m); err != nil {
+			return nil, err
+		}
+		raw = m
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	var p v1.Probe
+	if err := dec.Decode(&p); err != nil {
+		return nil, err
+	}
+
+	handlers := 0
+	for _, set := range []bool{p.Exec != nil, p.HTTPGet != nil, p.TCPSocket != nil, p.GRPC != nil} {
+		if set {
+			handlers++
+		}
+	}
+	if handlers != 1 {
+		return nil, fmt.Errorf("exactly one of exec, httpGet, tcpSocket or grpc must be set")
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// resolveSetContainer checks that name is a container at containersPath; an
+// empty name is allowed when there is exactly one container.
+func resolveSetContainer(obj *unstructured.Unstructured, containersPath []string, name string) (string, error) {
+	containers, _, _ := unstructured.NestedSlice(obj.Object, containersPath...)
+	var names []string
+	for _, c := range containers {
+		if m, ok := c.(map[string]any); ok {
+			names = append(names, fmtAny(m["name"]))
+		}
+	}
+	if name == "" {
+		if len(names) == 1 {
+			return names[0], nil
+		}
+		return "", fmt.Errorf("Error: container is required (containers: %s)", strings.Join(names, ", "))
+	}
+	if !stringInSlice(name, names) {
+		return "", fmt.Errorf("Error: container '%s' not found in %s/%s (containers: %s)", name, obj.GetKind(), obj.GetName(), strings.Join(names, ", "))
+	}
+	return name, nil
+}
@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var testDeploymentGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+func newTestDeployment() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "web", "namespace": "default"},
+		"spec":       map[string]any{"replicas": int64(1)},
+	}}
+}
+
+// newConflictingClient returns a fake dynamic client holding the test
+// deployment whose first conflicts updates fail with a Conflict.
+func newConflictingClient(conflicts int, updates *int) *dynamicfake.FakeDynamicClient {
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), newTestDeployment())
+	dyn.PrependReactor("update", "deployments", func(k8stesting.Action) (bool, runtime.Object, error) {
+		*updates++
+		if *updates <= conflicts {
+			return true, nil, apierrors.NewConflict(testDeploymentGVR.GroupResource(), "web", nil)
+		}
+		return false, nil, nil
+	})
+	return dyn
+}
+
+func setReplicas(n int64) func(*unstructured.Unstructured) error {
+	return func(o *unstructured.Unstructured) error {
+		return unstructured.SetNestedField(o.Object, n, "spec", "replicas")
+	}
+}
+
+func TestUpdateWithConflictRetryRetriesOnConflict(t *testing.T) {
+	updates := 0
+	dyn := newConflictingClient(1, &updates)
+	ri := dyn.Resource(testDeploymentGVR).Namespace("default")
+
+	obj, err := ri.Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mutated := 0
+	mutate := func(o *unstructured.Unstructured) error {
+		mutated++
+		return setReplicas(3)(o)
+	}
+	if err := mutate(obj); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := updateWithConflictRetry(context.Background(), ri, obj, "", mutate)
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if updates != 2 {
+		t.Errorf("update attempts = %d, want 2", updates)
+	}
+	if mutated != 2 {
+		t.Errorf("mutate ran %d time(s), want 2 (once more on the re-fetched object)", mutated)
+	}
+	if got, _, _ := unstructured.NestedInt64(updated.Object, "spec", "replicas"); got != 3 {
+		t.Errorf("spec.replicas = %d, want 3", got)
+	}
+}
+
+func TestUpdateWithConflictRetryGivesUp(t *testing.T) {
+	defer SetConflictRetries(conflictRetries)
+	SetConflictRetries(2)
+
+	updates := 0
+	dyn := newConflictingClient(1<<30, &updates)
+	ri := dyn.Resource(testDeploymentGVR).Namespace("default")
+
+	obj, err := ri.Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = updateWithConflictRetry(context.Background(), ri, obj, "", setReplicas(3))
+	if err == nil {
+		t.Fatal("expected an error when every update conflicts")
+	}
+	if !strings.Contains(err.Error(), "gave up after 3 attempt(s)") {
+		t.Errorf("error = %q, want it to report 3 attempts", err)
+	}
+	if updates != 3 {
+		t.Errorf("update attempts = %d, want 3 (1 + --conflict-retries)", updates)
+	}
+}
@@ -0,0 +1,248 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// K8sSetVolume mirrors parts of `kubectl set volume`: it adds a volume to the
+// pod spec together with a volumeMount in one container, or removes them, so
+// the two arrays stay consistent.
+//
+// Args:
+// - resource_type, name (or resource_name), volume (required); namespace defaults to defaultNamespace()
+// - action: "add" (default) or "remove"
+// - container: required when the pod template has more than one container
+// - add: type configmap|secret|emptydir|pvc (optional when the volume already exists),
+// source (ConfigMap/Secret/claim name; not for emptydir), mount_path (required),
+// sub_path, read_only (bool), overwrite (bool) to replace an existing volume or mount path
+// - remove: drops the container's mount (all containers' mounts when container is
+// empty) and the volume once nothing mounts it any more
+// - resource_version: optional optimistic-concurrency guard
+func K8sSetVolume(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType := getStringArg(args, "resource_type")
+	name := getStringArg(args, "name", "resource_name")
+	namespace := getStringArg(args, "namespace")
+	containerName := getStringArg(args, "container")
+	volumeName := getStringArg(args, "volume", "volume_name")
+	action := strings.ToLower(getStringArg(args, "action"))
+	volType := strings.ToLower(getStringArg(args, "type"))
+	source := getStringArg(args, "source")
+	mountPath := getStringArg(args, "mount_path")
+	subPath := getStringArg(args, "sub_path")
+	readOnly := boolFromArgs(args, "read_only", false)
+	overwrite := boolFromArgs(args, "overwrite", false)
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if strings.TrimSpace(volumeName) == "" {
+		return textErrorResult("volume is required"), nil, nil
+	}
+	if action == "" {
+		action = "add"
+	}
+	if action != "add" && action != "remove" {
+		return textErrorResult("Error: action must be add or remove"), nil, nil
+	}
+	var volSource map[string]any
+	if action == "add" {
+		if strings.TrimSpace(mountPath) == "" {
+			return textErrorResult("mount_path is required"), nil, nil
+		}
+		if volType != "" {
+			vs, err := volumeSource(volType, source)
+			if err != nil {
+				return textErrorResult("Error: " + err.Error()), nil, nil
+			}
+			volSource = vs
+		}
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	t, err := loadSetTarget(ctx, args, resourceType, name, namespace, "setting volumes")
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	if action == "add" || containerName != "" {
+		containerName, err = resolveSetContainer(t.obj, t.containersPath(), containerName)
+		if err != nil {
+			return textErrorResult(err.Error()), nil, nil
+		}
+	}
+
+	var out map[string]any
+	apply := func(o *unstructured.Unstructured) error {
+		volumesPath := append(append([]string{}, t.podSpecPath...), "volumes")
+		volumes, _, _ := unstructured.NestedSlice(o.Object, volumesPath...)
+		volIdx := -1
+		for i, v := range volumes {
+			if m, ok := v.(map[string]any); ok && fmtAny(m["name"]) == volumeName {
+				volIdx = i
+			}
+		}
+
+		out = map[string]any{"volume": volumeName, "action": action}
+		if action == "add" {
+			switch {
+			case volIdx < 0 && volSource == nil:
+				return fmt.Errorf("Error: volume '%s' does not exist; type is required to create it", volumeName)
+			case volIdx >= 0 && volSource != nil && !overwrite:
+				return fmt.Errorf("Error: volume '%s' already exists; set overwrite=true to replace it", volumeName)
+			case volSource != nil:
+				vol := map[string]any{"name": volumeName}
+				for k, v := range volSource {
+					vol[k] = v
+				}
+				if volIdx >= 0 {
+					volumes[volIdx] = vol
+				} else {
+					volumes = append(volumes, vol)
+				}
+				if err := unstructured.SetNestedSlice(o.Object, volumes, volumesPath...); err != nil {
+					return errors.New("Error:\n" + err.Error())
+				}
+			}
+
+			mount := map[string]any{"name": volumeName, "mountPath": mountPath}
+			if subPath != "" {
+				mount["subPath"] = subPath
+			}
+			if readOnly {
+				mount["readOnly"] = true
+			}
+			var mountErr error
+			if err := updateContainers(o.Object, t.containersPath(), func(c map[string]any) error {
+				if fmtAny(c["name"]) != containerName {
+					return nil
+				}
+				mounts, _ := c["volumeMounts"].([]any)
+				kept := mounts[:0]
+				for _, m := range mounts {
+					mm, _ := m.(map[string]any)
+					if fmtAny(mm["mountPath"]) == mountPath {
+						if !overwrite {
+							mountErr = fmt.Errorf("Error: %s is already mounted in container '%s' (volume '%s'); set overwrite=true to replace it", mountPath, containerName, fmtAny(mm["name"]))
+							return nil
+						}
+						continue
+					}
+					kept = append(kept, m)
+				}
+				c["volumeMounts"] = append(kept, mount)
+				return nil
+			}); err != nil {
+				return errors.New("Error:\n" + err.Error())
+			}
+			if mountErr != nil {
+				return mountErr
+			}
+			out["container"] = containerName
+			out["mount"] = mount
+		} else {
+			if volIdx < 0 {
+				return fmt.Errorf("Error: volume '%s' not found in %s/%s", volumeName, o.GetKind(), o.GetName())
+			}
+			removedFrom := []string{}
+			stillMounted := false
+			initPath := append(append([]string{}, t.podSpecPath...), "initContainers")
+			for _, path := range [][]string{t.containersPath(), initPath} {
+				if _, found, _ := unstructured.NestedSlice(o.Object, path...); !found {
+					continue
+				}
+				if err := updateContainers(o.Object, path, func(c map[string]any) error {
+					mounts, _ := c["volumeMounts"].([]any)
+					kept := mounts[:0]
+					for _, m := range mounts {
+						mm, _ := m.(map[string]any)
+						if fmtAny(mm["name"]) != volumeName {
+							kept = append(kept, m)
+							continue
+						}
+						if containerName != "" && fmtAny(c["name"]) != containerName {
+							stillMounted = true
+							kept = append(kept, m)
+							continue
+						}
+						removedFrom = append(removedFrom, fmtAny(c["name"]))
+					}
+					if len(kept) == 0 {
+						delete(c, "volumeMounts")
+					} else {
+						c["volumeMounts"] = kept
+					}
+					return nil
+				}); err != nil {
+					return errors.New("Error:\n" + err.Error())
+				}
+			}
+			if !stillMounted {
+				volumes = append(volumes[:volIdx], volumes[volIdx+1:]...)
+				if len(volumes) == 0 {
+					unstructured.RemoveNestedField(o.Object, volumesPath...)
+				} else if err := unstructured.SetNestedSlice(o.Object, volumes, volumesPath...); err != nil {
+					return errors.New("Error:\n" + err.Error())
+				}
+			}
+			out["unmounted_from"] = removedFrom
+			out["volume_removed"] = !stillMounted
+		}
+		return nil
+	}
+	if err := apply(t.obj); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	updated, err := t.update(ctx, getStringArg(args, "resource_version"), apply)
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	out["kind"] = updated.GetKind()
+	out["name"] = updated.GetName()
+	out["namespace"] = updated.GetNamespace()
+	out["resource_version"] = updated.GetResourceVersion()
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// volumeSource builds the volume source fields for the supported types.
+func volumeSource(volType, source string) (map[string]any, error) {
+	needSource := func() error {
+		if strings.TrimSpace(source) == "" {
+			return fmt.Errorf("source is required for type %s", volType)
+		}
+		return nil
+	}
+	switch volType {
+	case "configmap":
+		if err := needSource(); err != nil {
+			return nil, err
+		}
+		return map[string]any{"configMap": map[string]any{"name": source}}, nil
+	case "secret":
+		if err := needSource(); err != nil {
+			return nil, err
+		}
+		return map[string]any{"secret": map[string]any{"secretName": source}}, nil
+	case "pvc", "persistentvolumeclaim":
+		if err := needSource(); err != nil {
+			return nil, err
+		}
+		return map[string]any{"persistentVolumeClaim": map[string]any{"claimName": source}}, nil
+	case "emptydir":
+		return map[string]any{"emptyDir": map[string]any{}}, nil
+	}
+	return nil, fmt.Errorf("unsupported volume type '%s' (expected configmap, secret, emptydir or pvc)", volType)
+}
@@ -0,0 +1,203 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// Server-wide options. They are set once from flags in internal/server before
+// any tool is registered, and only read afterwards. The exception is the
+// per-session namespace of k8s_set_namespace at the end of this file.
+
+var deleteDisabled bool
+
+// SetDeleteDisabled records --disable-delete so write tools with a destructive
+// side path (e.g. apply prune) can refuse it.
+func SetDeleteDisabled(v bool) {
+	deleteDisabled = v
+}
+
+var secretRevealAllowed bool
+
+// SetSecretRevealAllowed records --allow-secret-reveal; without it tools that
+// read Secret values (e.g. k8s_container_env) only return them redacted.
+func SetSecretRevealAllowed(v bool) {
+	secretRevealAllowed = v
+}
+
+// protectedKinds are lower-cased kinds that mutating tools refuse to change
+// without confirm=true (see checkProtected).
+var protectedKinds = map[string]bool{}
+
+// SetProtectedKinds records --protected-kinds.
+func SetProtectedKinds(kinds []string) {
+	protectedKinds = map[string]bool{}
+	for _, k := range kinds {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			protectedKinds[k] = true
+		}
+	}
+}
+
+// conflictRetries is how often read-modify-write tools re-read and re-apply
+// their edit after a Conflict (see updateWithConflictRetry).
+var conflictRetries = 3
+
+// SetConflictRetries records --conflict-retries; negative values count as 0.
+func SetConflictRetries(n int) {
+	if n < 0 {
+		n = 0
+	}
+	conflictRetries = n
+}
+
+// conflictBackoff spaces the conflict retries like retry.DefaultRetry, with
+// conflictRetries retries after the first attempt.
+func conflictBackoff() wait.Backoff {
+	b := retry.DefaultRetry
+	b.Steps = conflictRetries + 1
+	return b
+}
+
+var useProtobuf bool
+
+// SetUseProtobuf records --protobuf: the typed clientset asks for protobuf
+// instead of JSON. It must be called before SetupClient.
+func SetUseProtobuf(v bool) {
+	useProtobuf = v
+}
+
+var streamProgress bool
+
+// SetStreamProgress enables progress-notification streaming of follow output;
+// it is set for the HTTP transports, where the client can see it live.
+func SetStreamProgress(v bool) {
+	streamProgress = v
+}
+
+var maxResponseBytes int
+
+// SetMaxResponseBytes records --max-response-bytes; 0 or less disables the cap.
+func SetMaxResponseBytes(n int) {
+	maxResponseBytes = n
+}
+
+// streamOutputCap bounds tools that accumulate streamed output (follow logs,
+// event watches): 1MB, or --max-response-bytes when that is smaller.
+func streamOutputCap() int {
+	const streamDefault = 1024 * 1024
+	if maxResponseBytes > 0 && maxResponseBytes < streamDefault {
+		return maxResponseBytes
+	}
+	return streamDefault
+}
+
+var toolTimeout time.Duration
+
+// SetToolTimeout records --tool-timeout, the default deadline of a tool call
+// (see toolContext); 0 or less disables it.
+func SetToolTimeout(d time.Duration) {
+	toolTimeout = d
+}
+
+// toolContext bounds a call by --tool-timeout so a slow API server cannot hang
+// it. Tools that stream or wait on purpose (follow logs, event watches,
+// rollout/job waits) do not use it and apply their own bounds.
+func toolContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if toolTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, toolTimeout)
+}
+
+var defaultNS string
+
+// SetDefaultNamespace records --namespace, the fallback for tools whose
+// namespace argument is empty.
+func SetDefaultNamespace(ns string) {
+	defaultNS = ns
+}
+
+// sessionNamespaces holds the k8s_set_namespace choice of each MCP session.
+// Over the HTTP transports many clients share one server, so the choice must
+// not leak from one session into another's unqualified calls. It lives only in
+// this process; the kubeconfig on disk is never changed. An entry stays until
+// its session closes ("" = no override).
+var (
+	sessionNSMu       sync.Mutex
+	sessionNamespaces = map[*mcp.ServerSession]string{}
+)
+
+// sessionKey carries the calling *mcp.ServerSession in a tool's context (see
+// AddTool).
+type sessionKey struct{}
+
+func withSession(ctx context.Context, req *mcp.CallToolRequest) context.Context {
+	if req == nil || req.Session == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, sessionKey{}, req.Session)
+}
+
+func sessionFromContext(ctx context.Context) *mcp.ServerSession {
+	ss, _ := ctx.Value(sessionKey{}).(*mcp.ServerSession)
+	return ss
+}
+
+// setSessionNamespace records ns for the session calling with ctx; "" drops
+// the override. The entry is removed when the session ends.
+func setSessionNamespace(ctx context.Context, ns string) {
+	ss := sessionFromContext(ctx)
+	if ss == nil {
+		return
+	}
+	sessionNSMu.Lock()
+	defer sessionNSMu.Unlock()
+	if _, tracked := sessionNamespaces[ss]; !tracked {
+		go func() {
+			_ = ss.Wait()
+			sessionNSMu.Lock()
+			delete(sessionNamespaces, ss)
+			sessionNSMu.Unlock()
+		}()
+	}
+	sessionNamespaces[ss] = ns
+}
+
+func sessionNamespace(ctx context.Context) string {
+	ss := sessionFromContext(ctx)
+	if ss == nil {
+		return ""
+	}
+	sessionNSMu.Lock()
+	defer sessionNSMu.Unlock()
+	return sessionNamespaces[ss]
+}
+
+// defaultNamespace is the namespace used when a tool's namespace arg is empty:
+// the calling session's k8s_set_namespace choice, --namespace, then the
+// kubeconfig context's namespace, then "default".
+func defaultNamespace(ctx context.Context) string {
+	ns, _ := defaultNamespaceSource(ctx)
+	return ns
+}
+
+// defaultNamespaceSource is defaultNamespace plus where the value came from.
+func defaultNamespaceSource(ctx context.Context) (string, string) {
+	if ns := sessionNamespace(ctx); ns != "" {
+		return ns, "k8s_set_namespace"
+	}
+	if defaultNS != "" {
+		return defaultNS, "--namespace"
+	}
+	if ns := getContextNamespace(); ns != "" {
+		return ns, "kubeconfig context"
+	}
+	return "default", "built-in default"
+}
@@ -0,0 +1,227 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type statusCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"last_transition_time,omitempty"`
+}
+
+type statusSummary struct {
+	Kind        string            `json:"kind"`
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Ready       *bool             `json:"ready"`
+	Available   *bool             `json:"available"`
+	Progressing *bool             `json:"progressing"`
+	Phase       string            `json:"phase,omitempty"`
+	QOSClass    string            `json:"qos_class,omitempty"`
+	Observed    *bool             `json:"observed_generation_current,omitempty"`
+	Conditions  []statusCondition `json:"conditions"`
+}
+
+// K8sStatus returns a normalized health summary for any object:
+// ready/available/progressing booleans (null when the object doesn't say) plus its conditions.
+func K8sStatus(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType, _ := args["resource_type"].(string)
+	name, _ := args["name"].(string)
+	namespace, _ := args["namespace"].(string)
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
+	}
+
+	var obj *unstructured.Unstructured
+	if namespaced {
+		obj, err = dyn.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	} else {
+		obj, err = dyn.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	b, _ := json.MarshalIndent(extractStatusSummary(obj), "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// extractStatusSummary reads status.conditions in either shape seen in the wild:
+// - the standard list of {type, status, reason, message}
+// - a map keyed by condition type (value is a condition object, a bool or a string)
+//
+// When there is no Ready/Available condition it falls back to replica counts and phase.
+func extractStatusSummary(obj *unstructured.Unstructured) statusSummary {
+	s := statusSummary{
+		Kind:       obj.GetKind(),
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		Conditions: []statusCondition{},
+	}
+
+	status, _, _ := unstructured.NestedMap(obj.Object, "status")
+
+	switch conds := status["conditions"].(type) {
+	case []any:
+		for _, c := range conds {
+			if cm, ok := c.(map[string]any); ok {
+				s.Conditions = append(s.Conditions, conditionFromMap(fmtAny(cm["type"]), cm))
+			}
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(conds))
+		for k := range conds {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			switch v := conds[k].(type) {
+			case map[string]any:
+				s.Conditions = append(s.Conditions, conditionFromMap(k, v))
+			default:
+				s.Conditions = append(s.Conditions, statusCondition{Type: k, Status: normalizeConditionStatus(v)})
+			}
+		}
+	}
+
+	for _, c := range s.Conditions {
+		switch strings.ToLower(c.Type) {
+		case "ready":
+			s.Ready = conditionTruth(c.Status)
+		case "available":
+			s.Available = conditionTruth(c.Status)
+		case "progressing":
+			s.Progressing = conditionTruth(c.Status)
+		}
+	}
+
+	if phase, ok := status["phase"].(string); ok {
+		s.Phase = phase
+	}
+
+	// Workloads without a Ready condition: compare ready vs desired replicas.
+	// readyReplicas is omitted by the API when zero, so only the desired count must be present.
+	if s.Ready == nil {
+		desired, ok := replicaCount(status, "replicas", "desiredNumberScheduled")
+		if specReplicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas"); found && ok {
+			desired = specReplicas
+		}
+		if ok {
+			ready, _ := replicaCount(status, "readyReplicas", "numberReady")
+			v := ready >= desired
+			s.Ready = &v
+		}
+	}
+	if s.Ready == nil && s.Phase != "" {
+		switch s.Phase {
+		case "Running", "Succeeded", "Active", "Bound":
+			v := true
+			s.Ready = &v
+		case "Pending", "Failed", "Unknown", "Lost", "Terminating":
+			v := false
+			s.Ready = &v
+		}
+	}
+
+	if og, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration"); found {
+		v := og >= obj.GetGeneration()
+		s.Observed = &v
+	}
+
+	// QoS class decides eviction order under node pressure.
+	if obj.GetKind() == "Pod" && obj.GetAPIVersion() == "v1" {
+		var pod v1.Pod
+		if runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod) == nil {
+			s.QOSClass = string(podQOSClass(&pod))
+		}
+	}
+
+	return s
+}
+
+func conditionFromMap(condType string, cm map[string]any) statusCondition {
+	return statusCondition{
+		Type:               condType,
+		Status:             normalizeConditionStatus(cm["status"]),
+		Reason:             fmtAny(cm["reason"]),
+		Message:            fmtAny(cm["message"]),
+		LastTransitionTime: fmtAny(cm["lastTransitionTime"]),
+	}
+}
+
+// normalizeConditionStatus maps bools and loose strings onto True/False/Unknown.
+func normalizeConditionStatus(v any) string {
+	switch t := v.(type) {
+	case bool:
+		if t {
+			return "True"
+		}
+		return "False"
+	case string:
+		switch strings.ToLower(strings.TrimSpace(t)) {
+		case "true", "yes":
+			return "True"
+		case "false", "no":
+			return "False"
+		case "", "unknown":
+			return "Unknown"
+		}
+		return t
+	}
+	return "Unknown"
+}
+
+func conditionTruth(status string) *bool {
+	switch status {
+	case "True":
+		v := true
+		return &v
+	case "False":
+		v := false
+		return &v
+	}
+	return nil
+}
+
+func replicaCount(status map[string]any, keys ...string) (int64, bool) {
+	for _, k := range keys {
+		if v, ok := status[k]; ok {
+			return toInt64(v), true
+		}
+	}
+	return 0, false
+}
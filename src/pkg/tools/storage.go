@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type pvcRow struct {
+	Namespace    string   `json:"namespace"`
+	Name         string   `json:"name"`
+	Phase        string   `json:"phase"`
+	Volume       string   `json:"volume,omitempty"`
+	StorageClass string   `json:"storage_class,omitempty"`
+	Requested    string   `json:"requested,omitempty"`
+	Capacity     string   `json:"capacity,omitempty"`
+	AccessModes  []string `json:"access_modes,omitempty"`
+	Problem      string   `json:"problem,omitempty"`
+	Events       []string `json:"events,omitempty"`
+}
+
+type pvRow struct {
+	Name          string   `json:"name"`
+	Phase         string   `json:"phase"`
+	Capacity      string   `json:"capacity,omitempty"`
+	StorageClass  string   `json:"storage_class,omitempty"`
+	ReclaimPolicy string   `json:"reclaim_policy,omitempty"`
+	AccessModes   []string `json:"access_modes,omitempty"`
+	Claim         string   `json:"claim,omitempty"`
+	Reason        string   `json:"reason,omitempty"`
+	Problem       string   `json:"problem,omitempty"`
+}
+
+// K8sStorage summarizes PVC binding: phase, bound PV, storage class, requested
+// vs actual capacity and access modes. Pending/Lost claims are flagged and carry
+// their recent events (provisioning failures, WaitForFirstConsumer, ...).
+//
+// Args:
+// - namespace defaults to defaultNamespace(); all_namespaces (bool)
+// - include_pvs (bool): also list cluster-scoped PersistentVolumes, flagging Released/Failed ones
+func K8sStorage(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	namespace, _ := args["namespace"].(string)
+	if boolFromArgs(args, "all_namespaces", false) {
+		namespace = metav1.NamespaceAll
+	} else if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+	includePVs := boolFromArgs(args, "include_pvs", false)
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	pvcs, err := cs.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return textErrorResult(formatK8sErr(err)), nil, nil
+	}
+
+	claims := []pvcRow{}
+	problems := 0
+	for i := range pvcs.Items {
+		c := &pvcs.Items[i]
+		r := pvcRow{
+			Namespace:   c.Namespace,
+			Name:        c.Name,
+			Phase:       string(c.Status.Phase),
+			Volume:      c.Spec.VolumeName,
+			AccessModes: accessModeStrings(c.Spec.AccessModes),
+		}
+		if c.Spec.StorageClassName != nil {
+			r.StorageClass = *c.Spec.StorageClassName
+		}
+		if q, ok := c.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+			r.Requested = q.String()
+		}
+		if q, ok := c.Status.Capacity[v1.ResourceStorage]; ok {
+			r.Capacity = q.String()
+		}
+
+		switch c.Status.Phase {
+		case v1.ClaimPending:
+			r.Problem = "claim is not bound"
+		case v1.ClaimLost:
+			r.Problem = "bound volume no longer exists"
+		}
+		if c.DeletionTimestamp != nil {
+			r.Problem = "terminating (likely held by a pod through the kubernetes.io/pvc-protection finalizer)"
+		}
+		if r.Problem != "" {
+			problems++
+			obj := &unstructured.Unstructured{}
+			obj.SetName(c.Name)
+			obj.SetNamespace(c.Namespace)
+			for _, e := range fetchEventsForObject(ctx, cs, obj) {
+				r.Events = append(r.Events, fmt.Sprintf("%s %s %s: %s", formatEventTime(e), e.Type, e.Reason, e.Message))
+			}
+		}
+		claims = append(claims, r)
+	}
+	sort.SliceStable(claims, func(i, j int) bool {
+		if (claims[i].Problem != "") != (claims[j].Problem != "") {
+			return claims[i].Problem != ""
+		}
+		if claims[i].Namespace != claims[j].Namespace {
+			return claims[i].Namespace < claims[j].Namespace
+		}
+		return claims[i].Name < claims[j].Name
+	})
+
+	out := map[string]any{
+		"claims":         claims,
+		"claim_count":    len(claims),
+		"problem_claims": problems,
+	}
+	if namespace != metav1.NamespaceAll {
+		out["namespace"] = namespace
+	}
+
+	if includePVs {
+		pvs, err := cs.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		volumes := []pvRow{}
+		for i := range pvs.Items {
+			pv := &pvs.Items[i]
+			r := pvRow{
+				Name:          pv.Name,
+				Phase:         string(pv.Status.Phase),
+				StorageClass:  pv.Spec.StorageClassName,
+				ReclaimPolicy: string(pv.Spec.PersistentVolumeReclaimPolicy),
+				AccessModes:   accessModeStrings(pv.Spec.AccessModes),
+				Reason:        pv.Status.Reason,
+			}
+			if q, ok := pv.Spec.Capacity[v1.ResourceStorage]; ok {
+				r.Capacity = q.String()
+			}
+			if ref := pv.Spec.ClaimRef; ref != nil {
+				r.Claim = ref.Namespace + "/" + ref.Name
+			}
+			switch pv.Status.Phase {
+			case v1.VolumeReleased:
+				r.Problem = "released: its claim was deleted; reclaim policy " + r.ReclaimPolicy + " keeps the data until the PV is cleaned up"
+			case v1.VolumeFailed:
+				r.Problem = "failed: " + pv.Status.Message
+			}
+			volumes = append(volumes, r)
+		}
+		sort.SliceStable(volumes, func(i, j int) bool { return volumes[i].Name < volumes[j].Name })
+		out["volumes"] = volumes
+	}
+
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
+
+// accessModeStrings uses kubectl's short forms (RWO, ROX, RWX, RWOP).
+func accessModeStrings(modes []v1.PersistentVolumeAccessMode) []string {
+	short := map[v1.PersistentVolumeAccessMode]string{
+		v1.ReadWriteOnce:    "RWO",
+		v1.ReadOnlyMany:     "ROX",
+		v1.ReadWriteMany:    "RWX",
+		v1.ReadWriteOncePod: "RWOP",
+	}
+	var out []string
+	for _, m := range modes {
+		if s, ok := short[m]; ok {
+			out = append(out, s)
+		} else {
+			out = append(out, strings.TrimSpace(string(m)))
+		}
+	}
+	return out
+}
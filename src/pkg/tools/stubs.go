@@ -3,8 +3,10 @@ package tools
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os/exec"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -14,11 +16,34 @@ import (
 // AddTool binds a tool name/description to a typed handler.
 // We use In=map[string]any and Out=any for now to avoid having to define schemas
 // until we port each Python module.
+//
+// The handler's context carries the calling session, which per-session state
+// such as the k8s_set_namespace choice is keyed by.
 func AddTool(srv *mcp.Server, name, desc string, h mcp.ToolHandlerFor[map[string]any, any]) {
 	mcp.AddTool(srv, &mcp.Tool{
 		Name:        name,
 		Description: desc,
-	}, h)
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		return h(withSession(ctx, req), req, args)
+	})
+}
+
+// AddReadTool is AddTool with the --tool-timeout deadline applied at the
+// handler boundary (see toolContext).
+func AddReadTool(srv *mcp.Server, name, desc string, h mcp.ToolHandlerFor[map[string]any, any]) {
+	AddTool(srv, name, desc, withToolTimeout(h))
+}
+
+func withToolTimeout(h mcp.ToolHandlerFor[map[string]any, any]) mcp.ToolHandlerFor[map[string]any, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		tctx, cancel := toolContext(ctx)
+		defer cancel()
+		res, out, err := h(tctx, req, args)
+		if errors.Is(tctx.Err(), context.DeadlineExceeded) && ctx.Err() == nil && (err != nil || res == nil || res.IsError) {
+			return textErrorResult(fmt.Sprintf("Error: tool call exceeded --tool-timeout (%s)", toolTimeout)), nil, nil
+		}
+		return res, out, err
+	}
 }
 
 var ErrNotImplemented = errors.New("not implemented yet (waiting for python module to port)")
@@ -33,19 +58,12 @@ func notImplementedTool(_ context.Context, _ *mcp.CallToolRequest, _ map[string]
 // ---- Tool stubs (we'll replace each with real logic) ----
 
 var (
-	K8sAuthWhoAmI    mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sDelete        mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sPatch         mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sLabel         mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sAnnotate      mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sExpose        mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sRun           mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sExecCommand   mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sScale         mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sAutoscale     mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sTaint         mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sUntaint       mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
-	K8sRolloutResume mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
+	K8sAuthWhoAmI mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
+	K8sExpose     mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
+	K8sRun        mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
+	K8sAutoscale  mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
+	K8sTaint      mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
+	K8sUntaint    mcp.ToolHandlerFor[map[string]any, any] = notImplementedTool
 )
 
 // ---- kubectl/helm tools ----
@@ -56,33 +74,17 @@ type CommandArgs struct {
 }
 
 // RegisterKubectlTool matches your python logic: blocks write/delete subcommands depending on flags.
-func RegisterKubectlTool(srv *mcp.Server, disableWrite, disableDelete bool) {
+// blocked lists further subcommands to refuse (e.g. "exec" under --disable-exec).
+func RegisterKubectlTool(srv *mcp.Server, disableWrite, disableDelete bool, blocked []string) {
 	mcp.AddTool(srv, &mcp.Tool{
 		Name:        "kubectl",
 		Description: "Run a kubectl command and return the output",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, args CommandArgs) (*mcp.CallToolResult, any, error) {
-		cmdStr := args.Command
-
-		writeOps := map[string]bool{
-			"create": true, "apply": true, "edit": true, "patch": true, "replace": true,
-			"scale": true, "autoscale": true, "label": true, "annotate": true,
-			"set": true, "rollout": true, "expose": true, "run": true,
-			"cordon": true, "delete": true, "uncordon": true, "drain": true,
-			"taint": true, "untaint": true, "cp": true, "exec": true, "port-forward": true,
+		if msg := kubectlRefusal(args.Command, disableWrite, disableDelete, blocked); msg != "" {
+			return textErrorResult(msg), nil, nil
 		}
-		deleteOps := map[string]bool{"delete": true}
 
-		sub := firstSubcommand(cmdStr, "kubectl")
-		if sub != "" {
-			if disableDelete && deleteOps[sub] {
-				return textErrorResult("Error: Write operations are not allowed. Cannot execute kubectl delete command."), nil, nil
-			}
-			if disableWrite && writeOps[sub] {
-				return textErrorResult("Error: Write operations are not allowed. Cannot execute kubectl " + sub + " command."), nil, nil
-			}
-		}
-
-		out, err := runCommand("kubectl", cmdStr)
+		out, err := runCommand("kubectl", args.Command)
 		if err != nil {
 			return textErrorResult(out), nil, nil
 		}
@@ -95,27 +97,13 @@ func RegisterHelmTool(srv *mcp.Server, disableWrite bool) {
 		Name:        "helm",
 		Description: "Run a helm command and return the output",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, args CommandArgs) (*mcp.CallToolResult, any, error) {
-		cmdStr := args.Command
-
-		writeOps := map[string]bool{
-			"install": true, "upgrade": true, "uninstall": true, "rollback": true,
-			"push": true, "create": true, "package": true,
-			"repo add": true, "repo update": true, "repo remove": true,
-			"dependency update": true,
-			"plugin install":    true, "plugin uninstall": true,
-		}
-
 		if disableWrite {
-			sub1, sub2 := firstTwoSubcommands(cmdStr, "helm")
-			if sub1 != "" && writeOps[sub1] {
-				return textErrorResult("Error: Write operations are not allowed. Cannot execute helm " + sub1 + " command."), nil, nil
-			}
-			if sub1 != "" && sub2 != "" && writeOps[sub1+" "+sub2] {
-				return textErrorResult("Error: Write operations are not allowed. Cannot execute helm " + sub1 + " " + sub2 + " command."), nil, nil
+			if msg := helmRefusal(args.Command); msg != "" {
+				return textErrorResult(msg), nil, nil
 			}
 		}
 
-		out, err := runCommand("helm", cmdStr)
+		out, err := runCommand("helm", args.Command)
 		if err != nil {
 			return textErrorResult(out), nil, nil
 		}
@@ -128,46 +116,178 @@ func RegisterHelmTool(srv *mcp.Server, disableWrite bool) {
 func textOKResult(s string) *mcp.CallToolResult {
 	return &mcp.CallToolResult{
 		IsError: false,
-		Content: []mcp.Content{&mcp.TextContent{Text: s}},
+		Content: []mcp.Content{&mcp.TextContent{Text: truncateOutput(s)}},
 	}
 }
 
 func textErrorResult(s string) *mcp.CallToolResult {
 	return &mcp.CallToolResult{
 		IsError: true,
-		Content: []mcp.Content{&mcp.TextContent{Text: s}},
+		Content: []mcp.Content{&mcp.TextContent{Text: truncateOutput(s)}},
 	}
 }
 
-func firstSubcommand(command, bin string) string {
-	parts := strings.Fields(strings.TrimSpace(command))
-	if len(parts) == 0 {
+// truncateOutput applies --max-response-bytes to every tool result, cutting on a
+// UTF-8 boundary and appending the standard marker.
+func truncateOutput(s string) string {
+	if maxResponseBytes <= 0 || len(s) <= maxResponseBytes {
+		return s
+	}
+	cut := maxResponseBytes
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + truncatedMarker(len(s)-cut)
+}
+
+// truncatedMarker is the one truncation notice used by all tools. omitted < 0
+// means the size of the rest is unknown (e.g. a capped stream).
+func truncatedMarker(omitted int) string {
+	if omitted < 0 {
+		return "\n... output truncated ...\n"
+	}
+	return fmt.Sprintf("\n... output truncated (%d bytes omitted) ...\n", omitted)
+}
+
+var kubectlWriteOps = map[string]bool{
+	"create": true, "apply": true, "edit": true, "patch": true, "replace": true,
+	"scale": true, "autoscale": true, "label": true, "annotate": true,
+	"set": true, "rollout": true, "expose": true, "run": true,
+	"cordon": true, "delete": true, "uncordon": true, "drain": true,
+	"taint": true, "untaint": true, "cp": true, "exec": true, "port-forward": true,
+}
+
+var helmWriteOps = map[string]bool{
+	"install": true, "upgrade": true, "uninstall": true, "rollback": true,
+	"push": true, "create": true, "package": true,
+	"repo add": true, "repo update": true, "repo remove": true,
+	"dependency update": true,
+	"plugin install":    true, "plugin uninstall": true,
+}
+
+// kubectlRefusal returns the error to report for a kubectl command line that
+// the server's flags forbid, or "" if it may run.
+func kubectlRefusal(command string, disableWrite, disableDelete bool, blocked []string) string {
+	if !disableWrite && !disableDelete && len(blocked) == 0 {
 		return ""
 	}
-	if parts[0] == bin {
-		parts = parts[1:]
+	words, unknown := commandWords(command, "kubectl", kubectlGlobalFlags)
+	if len(words) == 0 {
+		if unknown != "" {
+			return "Error: cannot tell which kubectl subcommand follows the unrecognized flag " + unknown + "; put the subcommand first."
+		}
+		return ""
+	}
+	sub := words[0]
+	if disableDelete && sub == "delete" {
+		return "Error: Write operations are not allowed. Cannot execute kubectl delete command."
+	}
+	if disableWrite && kubectlWriteOps[sub] {
+		return "Error: Write operations are not allowed. Cannot execute kubectl " + sub + " command."
+	}
+	if stringInSlice(sub, blocked) {
+		return "Error: kubectl " + sub + " is disabled on this server."
+	}
+	return ""
+}
+
+// helmRefusal is kubectlRefusal for helm under --disable-write.
+func helmRefusal(command string) string {
+	words, unknown := commandWords(command, "helm", helmGlobalFlags)
+	if len(words) == 0 {
+		if unknown != "" {
+			return "Error: cannot tell which helm subcommand follows the unrecognized flag " + unknown + "; put the subcommand first."
+		}
+		return ""
 	}
-	if len(parts) == 0 {
+	if helmWriteOps[words[0]] {
+		return "Error: Write operations are not allowed. Cannot execute helm " + words[0] + " command."
+	}
+	if len(words) > 1 {
+		if op := words[0] + " " + words[1]; helmWriteOps[op] {
+			return "Error: Write operations are not allowed. Cannot execute helm " + op + " command."
+		}
 		return ""
 	}
-	return parts[0]
+	// "helm repo --weird add": the second word is hidden behind a flag.
+	if unknown != "" {
+		for op := range helmWriteOps {
+			if strings.HasPrefix(op, words[0]+" ") {
+				return "Error: cannot tell which helm " + words[0] + " subcommand follows the unrecognized flag " + unknown + "; put the subcommand first."
+			}
+		}
+	}
+	return ""
 }
 
-func firstTwoSubcommands(command, bin string) (string, string) {
+// kubectlGlobalFlags maps each kubectl global flag to whether it takes a
+// value. Flags missing here stop commandWords, since whether they consume
+// the next word is unknown.
+var kubectlGlobalFlags = map[string]bool{
+	"--as": true, "--as-group": true, "--as-uid": true, "--cache-dir": true,
+	"--certificate-authority": true, "--client-certificate": true, "--client-key": true,
+	"--cluster": true, "--context": true, "--kubeconfig": true, "--kuberc": true,
+	"-n": true, "--namespace": true, "--password": true, "--profile": true,
+	"--profile-output": true, "--request-timeout": true, "-s": true, "--server": true,
+	"--tls-server-name": true, "--token": true, "--user": true, "--username": true,
+	"-v": true, "--v": true, "--vmodule": true, "--log-backtrace-at": true,
+	"--log-dir": true, "--log-file": true, "--log-file-max-size": true,
+	"--log-flush-frequency": true, "--stderrthreshold": true,
+
+	"--insecure-skip-tls-verify": false, "--match-server-version": false,
+	"--warnings-as-errors": false, "--disable-compression": false,
+	"--add-dir-header": false, "--alsologtostderr": false, "--logtostderr": false,
+	"--one-output": false, "--skip-headers": false, "--skip-log-headers": false,
+	"-h": false, "--help": false,
+}
+
+// helmGlobalFlags is kubectlGlobalFlags for helm.
+var helmGlobalFlags = map[string]bool{
+	"--burst-limit": true, "--color": true, "--colour": true, "--content-cache": true,
+	"--kube-apiserver": true, "--kube-as-group": true, "--kube-as-user": true,
+	"--kube-ca-file": true, "--kube-context": true, "--kube-tls-server-name": true,
+	"--kube-token": true, "--kubeconfig": true, "-n": true, "--namespace": true,
+	"--qps": true, "--registry-config": true, "--repository-cache": true,
+	"--repository-config": true,
+
+	"--debug": false, "--kube-insecure-skip-tls-verify": false,
+	"-h": false, "--help": false,
+}
+
+// commandWords returns the non-flag words of a command line, without the
+// leading binary name, so that global flags placed before the subcommand
+// (e.g. "kubectl -n foo exec ...") cannot hide it from the checks.
+// globalFlags maps known flags to whether they take a value. At the first
+// flag not in it, commandWords stops and returns that flag as unknown: it
+// can't tell whether the next word is the flag's value or a subcommand.
+func commandWords(command, bin string, globalFlags map[string]bool) (words []string, unknown string) {
 	parts := strings.Fields(strings.TrimSpace(command))
-	if len(parts) == 0 {
-		return "", ""
-	}
-	if parts[0] == bin {
+	if len(parts) > 0 && parts[0] == bin {
 		parts = parts[1:]
 	}
-	if len(parts) == 0 {
-		return "", ""
-	}
-	if len(parts) == 1 {
-		return parts[0], ""
+	for i := 0; i < len(parts); i++ {
+		p := parts[i]
+		if p == "--" {
+			return append(words, parts[i+1:]...), ""
+		}
+		if !strings.HasPrefix(p, "-") || p == "-" {
+			words = append(words, p)
+			continue
+		}
+		name, _, hasValue := strings.Cut(p, "=")
+		if !hasValue && !strings.HasPrefix(p, "--") && len(p) > 2 {
+			// -nfoo: short flag with its value attached
+			name, hasValue = p[:2], true
+		}
+		takesValue, known := globalFlags[name]
+		if !known {
+			return words, p
+		}
+		if takesValue && !hasValue {
+			i++ // the flag's value
+		}
 	}
-	return parts[0], parts[1]
+	return words, ""
 }
 
 func runCommand(binary string, full string) (string, error) {
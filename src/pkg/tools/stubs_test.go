@@ -0,0 +1,66 @@
+package tools
+
+import "testing"
+
+func TestKubectlRefusal(t *testing.T) {
+	blocked := []string{"exec", "attach", "debug", "cp"}
+	tests := []struct {
+		command string
+		refused bool
+	}{
+		{"get pods -A", false},
+		{"kubectl get pods -o yaml", false},
+		{"-n kube-system get pods", false},
+		{"delete pod foo", true},
+		{"kubectl -n foo delete pod bar", true},
+		{"--namespace=foo delete pod bar", true},
+		{"-nfoo delete pod bar", true},
+		{"--context prod --insecure-skip-tls-verify apply -f x.yaml", true},
+		{"--logtostderr exec foo -- sh", true},
+		{"-v 9 cp foo:/etc/passwd .", true},
+		{"-- exec foo -- sh", true},
+		// Unknown flags before the subcommand fail closed.
+		{"--not-a-flag delete pod foo", true},
+		{"--not-a-flag get pods", true},
+		{"-x exec foo -- sh", true},
+	}
+	for _, tt := range tests {
+		got := kubectlRefusal(tt.command, true, true, blocked) != ""
+		if got != tt.refused {
+			t.Errorf("kubectlRefusal(%q) refused=%v, want %v", tt.command, got, tt.refused)
+		}
+	}
+
+	if msg := kubectlRefusal("--not-a-flag delete pod foo", false, false, nil); msg != "" {
+		t.Errorf("with no restrictions the command should pass, got %q", msg)
+	}
+}
+
+func TestHelmRefusal(t *testing.T) {
+	tests := []struct {
+		command string
+		refused bool
+	}{
+		{"list -A", false},
+		{"helm status foo -n bar", false},
+		{"repo list", false},
+		{"--kube-context prod get values foo", false},
+		{"uninstall foo", true},
+		{"helm --kube-insecure-skip-tls-verify uninstall foo", true},
+		{"--debug upgrade foo ./chart", true},
+		{"-n foo install bar ./chart", true},
+		{"--kube-context=prod rollback foo 1", true},
+		{"--burst-limit 200 repo add foo https://example.com", true},
+		{"repo --debug update", true},
+		// Unknown flags before the subcommand fail closed.
+		{"--not-a-flag uninstall foo", true},
+		{"--not-a-flag list", true},
+		{"repo --not-a-flag add foo https://example.com", true},
+	}
+	for _, tt := range tests {
+		got := helmRefusal(tt.command) != ""
+		if got != tt.refused {
+			t.Errorf("helmRefusal(%q) refused=%v, want %v", tt.command, got, tt.refused)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// K8sSuspend is one suspend verb across the kinds that support it:
+// - cronjob: spec.suspend=true (no new Jobs are scheduled)
+// - job: spec.suspend=true (running pods are terminated until resumed)
+// - deployment: spec.paused=true, as k8s_rollout_pause
+//
+// Args: resource_type, name required; namespace defaults to defaultNamespace();
+// confirm=true is needed if the kind is in --protected-kinds.
+func K8sSuspend(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	return k8sSetSuspended(ctx, args, true)
+}
+
+// K8sResume reverses K8sSuspend; arguments are the same.
+func K8sResume(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	return k8sSetSuspended(ctx, args, false)
+}
+
+func k8sSetSuspended(ctx context.Context, args map[string]any, suspend bool) (*mcp.CallToolResult, any, error) {
+	resourceType, _ := args["resource_type"].(string)
+	name, _ := args["name"].(string)
+	namespace, _ := args["namespace"].(string)
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	var (
+		kind  string
+		gvr   schema.GroupVersionResource
+		state bool
+	)
+	switch strings.ToLower(resourceType) {
+	case "cronjob", "cronjobs", "cj":
+		kind, gvr = "CronJob", schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}
+	case "job", "jobs":
+		kind, gvr = "Job", schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+	case "deployment", "deployments", "deploy":
+		kind, gvr = "Deployment", schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	default:
+		return textErrorResult(fmt.Sprintf("Error: resource type '%s' cannot be suspended (supported: cronjob, job, deployment)", resourceType)), nil, nil
+	}
+	action := "resuming"
+	if suspend {
+		action = "suspending"
+	}
+	if err := checkProtected(gvr, name, args, action); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend))
+	switch kind {
+	case "CronJob":
+		cj, err := cs.BatchV1().CronJobs(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		state = cj.Spec.Suspend != nil && *cj.Spec.Suspend
+	case "Job":
+		job, err := cs.BatchV1().Jobs(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		state = job.Spec.Suspend != nil && *job.Spec.Suspend
+	case "Deployment":
+		if err := setDeploymentPaused(ctx, cs, namespace, name, suspend); err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		state = suspend
+	}
+
+	b, _ := json.MarshalIndent(map[string]any{
+		"kind":      kind,
+		"name":      name,
+		"namespace": namespace,
+		"suspended": state,
+	}, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
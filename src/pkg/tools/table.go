@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// tableAccept asks the API server for its server-side printing (the columns
+// `kubectl get` shows), falling back to plain JSON for servers that lack it.
+const tableAccept = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json"
+
+// getTable fetches gvr as a meta.k8s.io/v1 Table and renders it as aligned
+// text. An empty name lists; an empty namespace lists across namespaces and adds
+// a NAMESPACE column. wide includes the columns kubectl hides without -o wide,
+// plus a QOS column (see podQOSClass) for pods.
+func getTable(ctx context.Context, disc discovery.DiscoveryInterface, gvr schema.GroupVersionResource, namespaced bool, namespace, name string, wide bool) (string, error) {
+	segs := []string{"/apis", gvr.Group, gvr.Version}
+	if gvr.Group == "" {
+		segs = []string{"/api", gvr.Version}
+	}
+	if namespaced && namespace != "" {
+		segs = append(segs, "namespaces", namespace)
+	}
+	segs = append(segs, gvr.Resource)
+	if name != "" {
+		segs = append(segs, name)
+	}
+
+	showNamespace := namespaced && namespace == ""
+	showQOS := wide && gvr.Group == "" && gvr.Resource == "pods"
+	req := disc.RESTClient().Get().AbsPath(segs...).SetHeader("Accept", tableAccept)
+	if showQOS {
+		req = req.Param("includeObject", "Object")
+	} else if showNamespace {
+		req = req.Param("includeObject", "Metadata")
+	}
+	raw, err := req.Do(ctx).Raw()
+	if err != nil {
+		return "", err
+	}
+
+	var table metav1.Table
+	if err := json.Unmarshal(raw, &table); err != nil {
+		return "", fmt.Errorf("decode table: %w", err)
+	}
+	if table.Kind != "Table" {
+		return "", fmt.Errorf("server did not return a Table for %s (got kind %q)", gvr.Resource, table.Kind)
+	}
+	if len(table.Rows) == 0 {
+		if namespace != "" {
+			return fmt.Sprintf("No resources found in %s namespace.", namespace), nil
+		}
+		return "No resources found", nil
+	}
+
+	var cols []int
+	for i, c := range table.ColumnDefinitions {
+		if wide || c.Priority == 0 {
+			cols = append(cols, i)
+		}
+	}
+
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 3, ' ', 0)
+
+	var header []string
+	if showNamespace {
+		header = append(header, "NAMESPACE")
+	}
+	for _, i := range cols {
+		header = append(header, strings.ToUpper(table.ColumnDefinitions[i].Name))
+	}
+	if showQOS {
+		header = append(header, "QOS")
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, row := range table.Rows {
+		var fields []string
+		if showNamespace {
+			ns := ""
+			var meta metav1.PartialObjectMetadata
+			if len(row.Object.Raw) > 0 && json.Unmarshal(row.Object.Raw, &meta) == nil {
+				ns = meta.Namespace
+			}
+			fields = append(fields, ns)
+		}
+		for _, i := range cols {
+			cell := "<none>"
+			if i < len(row.Cells) && row.Cells[i] != nil {
+				cell = fmtAny(row.Cells[i])
+			}
+			fields = append(fields, cell)
+		}
+		if showQOS {
+			qos := "<unknown>"
+			var pod v1.Pod
+			if len(row.Object.Raw) > 0 && json.Unmarshal(row.Object.Raw, &pod) == nil {
+				qos = string(podQOSClass(&pod))
+			}
+			fields = append(fields, qos)
+		}
+		fmt.Fprintln(w, strings.Join(fields, "\t"))
+	}
+	_ = w.Flush()
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
@@ -20,24 +20,49 @@ type topNodeRow struct {
 	Name   string `json:"name"`
 	CPU    string `json:"cpu"`
 	Memory string `json:"memory"`
+
+	cpuMilli int64
+	memBytes int64
+	cpuPct   float64
+	memPct   float64
 }
 
 type topPodRow struct {
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
+	Container string `json:"container,omitempty"`
 	CPU       string `json:"cpu"`
 	Memory    string `json:"memory"`
+
+	cpuMilli int64
+	memBytes int64
+}
+
+// topPodFilter narrows k8s_top_pods output after metrics are joined to pods.
+// Zero values disable a filter.
+type topPodFilter struct {
+	minCPUMilli  int64
+	minMemBytes  int64
+	topN         int
+	perContainer bool
 }
 
 // K8sTopNodes: MCP tool handler.
 // Args (compatible with your python): sort_by
+// Extra args: sort_mode "percent" (default, share of capacity) or "absolute" (millicores/bytes)
 func K8sTopNodes(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	if err := SetupClient(ctx); err != nil {
 		return textErrorResult(err.Error()), nil, nil
 	}
 
 	sortBy := getStringArg(args, "sort_by", "sortBy")
-	out, err := k8sTopNodes(ctx, sortBy)
+	sortMode := strings.ToLower(getStringArg(args, "sort_mode", "sortMode"))
+	switch sortMode {
+	case "", "percent", "absolute":
+	default:
+		return textErrorResult(fmt.Sprintf("Error: unsupported sort_mode '%s' (expected percent or absolute)", sortMode)), nil, nil
+	}
+	out, err := k8sTopNodes(ctx, sortBy, sortMode)
 	if err != nil {
 		return textErrorResult(err.Error()), nil, nil
 	}
@@ -46,6 +71,10 @@ func K8sTopNodes(ctx context.Context, _ *mcp.CallToolRequest, args map[string]an
 
 // K8sTopPods: MCP tool handler.
 // Args (compatible with your python): namespace, all_namespaces, sort_by, selector
+// Extra args:
+// - threshold_cpu (e.g. "500m", "1") / threshold_memory (e.g. "512Mi"): only rows above all given thresholds
+// - top_n: keep the first N rows after sorting (sorts by cpu when sort_by is empty)
+// - containers (bool): one row per container instead of per pod
 func K8sTopPods(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 	if err := SetupClient(ctx); err != nil {
 		return textErrorResult(err.Error()), nil, nil
@@ -56,14 +85,33 @@ func K8sTopPods(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any
 	sortBy := getStringArg(args, "sort_by", "sortBy")
 	selector := getStringArg(args, "selector")
 
-	out, err := k8sTopPods(ctx, namespace, allNamespaces, sortBy, selector)
+	filter := topPodFilter{
+		topN:         intFromArgsDefault(args, "top_n", 0),
+		perContainer: boolFromArgs(args, "containers", false),
+	}
+	if v := getStringArg(args, "threshold_cpu"); v != "" {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return textErrorResult(fmt.Sprintf("Error: invalid threshold_cpu %q: %v", v, err)), nil, nil
+		}
+		filter.minCPUMilli = q.MilliValue()
+	}
+	if v := getStringArg(args, "threshold_memory"); v != "" {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return textErrorResult(fmt.Sprintf("Error: invalid threshold_memory %q: %v", v, err)), nil, nil
+		}
+		filter.minMemBytes = q.Value()
+	}
+
+	out, err := k8sTopPods(ctx, namespace, allNamespaces, sortBy, selector, filter)
 	if err != nil {
 		return textErrorResult(err.Error()), nil, nil
 	}
 	return textOKResult(out), nil, nil
 }
 
-func k8sTopNodes(ctx context.Context, sortBy string) (string, error) {
+func k8sTopNodes(ctx context.Context, sortBy, sortMode string) (string, error) {
 	cs, err := getClient()
 	if err != nil {
 		return "", err
@@ -130,18 +178,30 @@ func k8sTopNodes(ctx context.Context, sortBy string) (string, error) {
 			Name:   node.Name,
 			CPU:    fmt.Sprintf("%dm (%.0f%%)", usageMil, cpuPct),
 			Memory: fmt.Sprintf("%s (%.0f%%)", formatBytesHuman(usageBytes), memPct),
+
+			cpuMilli: usageMil,
+			memBytes: usageBytes,
+			cpuPct:   cpuPct,
+			memPct:   memPct,
 		})
 	}
 
+	absolute := sortMode == "absolute"
 	sortBy = strings.ToLower(strings.TrimSpace(sortBy))
 	switch sortBy {
 	case "cpu":
-		sort.Slice(out, func(i, j int) bool {
-			return extractPct(out[i].CPU) > extractPct(out[j].CPU)
+		sort.SliceStable(out, func(i, j int) bool {
+			if absolute {
+				return out[i].cpuMilli > out[j].cpuMilli
+			}
+			return out[i].cpuPct > out[j].cpuPct
 		})
 	case "memory":
-		sort.Slice(out, func(i, j int) bool {
-			return extractPct(out[i].Memory) > extractPct(out[j].Memory)
+		sort.SliceStable(out, func(i, j int) bool {
+			if absolute {
+				return out[i].memBytes > out[j].memBytes
+			}
+			return out[i].memPct > out[j].memPct
 		})
 	}
 
@@ -152,7 +212,7 @@ func k8sTopNodes(ctx context.Context, sortBy string) (string, error) {
 	return string(b), nil
 }
 
-func k8sTopPods(ctx context.Context, namespace string, allNamespaces bool, sortBy string, selector string) (string, error) {
+func k8sTopPods(ctx context.Context, namespace string, allNamespaces bool, sortBy string, selector string, filter topPodFilter) (string, error) {
 	cs, err := getClient()
 	if err != nil {
 		return "", err
@@ -163,7 +223,7 @@ func k8sTopPods(ctx context.Context, namespace string, allNamespaces bool, sortB
 	}
 
 	if !allNamespaces && strings.TrimSpace(namespace) == "" {
-		namespace = "default"
+		namespace = defaultNamespace(ctx)
 	}
 
 	// pods list (typed, for selection + namespace/name)
@@ -204,29 +264,9 @@ func k8sTopPods(ctx context.Context, namespace string, allNamespaces bool, sortB
 		}
 	}
 
-	// metrics list (dynamic)
-	gvr := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
-
-	var metricsList *unstructured.UnstructuredList
-	if allNamespaces {
-		ml, err := dyn.Resource(gvr).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			return "", fmt.Errorf("list pod metrics (all namespaces): %w", err)
-		}
-		metricsList = ml
-	} else {
-		ml, err := dyn.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			return "", fmt.Errorf("list pod metrics in namespace %q: %w", namespace, err)
-		}
-		metricsList = ml
-	}
-
-	metricsByNSName := map[string]*unstructured.Unstructured{}
-	for i := range metricsList.Items {
-		m := &metricsList.Items[i]
-		key := m.GetNamespace() + "/" + m.GetName()
-		metricsByNSName[key] = m
+	metricsByNSName, err := podMetricsByKey(ctx, dyn, namespace, allNamespaces)
+	if err != nil {
+		return "", err
 	}
 
 	out := make([]topPodRow, 0, len(pods))
@@ -238,6 +278,21 @@ func k8sTopPods(ctx context.Context, namespace string, allNamespaces bool, sortB
 			continue
 		}
 
+		if filter.perContainer {
+			for _, c := range containerUsages(m) {
+				out = append(out, topPodRow{
+					Name:      p.Name,
+					Namespace: p.Namespace,
+					Container: c.name,
+					CPU:       fmt.Sprintf("%dm", c.milli),
+					Memory:    formatBytesHuman(c.bytes),
+					cpuMilli:  c.milli,
+					memBytes:  c.bytes,
+				})
+			}
+			continue
+		}
+
 		totalMil, totalBytes, ok := sumPodUsage(m)
 		if !ok {
 			continue
@@ -248,21 +303,41 @@ func k8sTopPods(ctx context.Context, namespace string, allNamespaces bool, sortB
 			Namespace: p.Namespace,
 			CPU:       fmt.Sprintf("%dm", totalMil),
 			Memory:    formatBytesHuman(totalBytes),
+			cpuMilli:  totalMil,
+			memBytes:  totalBytes,
 		})
 	}
 
+	if filter.minCPUMilli > 0 || filter.minMemBytes > 0 {
+		kept := out[:0]
+		for _, r := range out {
+			if (filter.minCPUMilli == 0 || r.cpuMilli > filter.minCPUMilli) &&
+				(filter.minMemBytes == 0 || r.memBytes > filter.minMemBytes) {
+				kept = append(kept, r)
+			}
+		}
+		out = kept
+	}
+
 	sortBy = strings.ToLower(strings.TrimSpace(sortBy))
+	if sortBy == "" && filter.topN > 0 {
+		sortBy = "cpu"
+	}
 	switch sortBy {
 	case "cpu":
-		sort.Slice(out, func(i, j int) bool {
-			return parseMilli(out[i].CPU) > parseMilli(out[j].CPU)
+		sort.SliceStable(out, func(i, j int) bool {
+			return out[i].cpuMilli > out[j].cpuMilli
 		})
 	case "memory":
-		sort.Slice(out, func(i, j int) bool {
-			return parseMemBytes(out[i].Memory) > parseMemBytes(out[j].Memory)
+		sort.SliceStable(out, func(i, j int) bool {
+			return out[i].memBytes > out[j].memBytes
 		})
 	}
 
+	if filter.topN > 0 && len(out) > filter.topN {
+		out = out[:filter.topN]
+	}
+
 	b, err := json.MarshalIndent(out, "", "  ")
 	if err != nil {
 		return "", err
@@ -270,6 +345,62 @@ func k8sTopPods(ctx context.Context, namespace string, allNamespaces bool, sortB
 	return string(b), nil
 }
 
+// podMetricsByKey lists metrics.k8s.io PodMetrics keyed by "namespace/name".
+func podMetricsByKey(ctx context.Context, dyn dynamic.Interface, namespace string, allNamespaces bool) (map[string]*unstructured.Unstructured, error) {
+	gvr := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+
+	var metricsList *unstructured.UnstructuredList
+	if allNamespaces {
+		ml, err := dyn.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("list pod metrics (all namespaces): %w", err)
+		}
+		metricsList = ml
+	} else {
+		ml, err := dyn.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("list pod metrics in namespace %q: %w", namespace, err)
+		}
+		metricsList = ml
+	}
+
+	metricsByNSName := map[string]*unstructured.Unstructured{}
+	for i := range metricsList.Items {
+		m := &metricsList.Items[i]
+		key := m.GetNamespace() + "/" + m.GetName()
+		metricsByNSName[key] = m
+	}
+	return metricsByNSName, nil
+}
+
+type containerUsage struct {
+	name  string
+	milli int64
+	bytes int64
+}
+
+// containerUsages returns per-container usage from a PodMetrics object.
+func containerUsages(m *unstructured.Unstructured) []containerUsage {
+	containers, _, _ := unstructured.NestedSlice(m.Object, "containers")
+	out := make([]containerUsage, 0, len(containers))
+	for _, c := range containers {
+		cm, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		usage, _ := cm["usage"].(map[string]any)
+		cu := containerUsage{name: fmtAny(cm["name"])}
+		if q, err := resource.ParseQuantity(fmtAny(usage["cpu"])); err == nil {
+			cu.milli = q.MilliValue()
+		}
+		if q, err := resource.ParseQuantity(fmtAny(usage["memory"])); err == nil {
+			cu.bytes = q.Value()
+		}
+		out = append(out, cu)
+	}
+	return out
+}
+
 func extractNodeUsage(m *unstructured.Unstructured) (cpu resource.Quantity, mem resource.Quantity, ok bool) {
 	usage, found, err := unstructured.NestedStringMap(m.Object, "usage")
 	if err != nil || !found {
@@ -328,48 +459,29 @@ func sumPodUsage(m *unstructured.Unstructured) (totalMil int64, totalBytes int64
 	return mil, bytes, true
 }
 
-func formatBytesHuman(b int64) string {
-	const (
-		mi = 1024 * 1024
-		gi = 1024 * 1024 * 1024
-	)
-	if b >= gi {
-		return fmt.Sprintf("%.0fGi", float64(b)/float64(gi))
-	}
-	return fmt.Sprintf("%.0fMi", float64(b)/float64(mi))
+// memoryUnits are the binary suffixes formatBytesHuman emits, largest first.
+var memoryUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"Ti", 1 << 40},
+	{"Gi", 1 << 30},
+	{"Mi", 1 << 20},
+	{"Ki", 1 << 10},
 }
 
-func extractPct(s string) float64 {
-	// expects "... (NN%)"
-	i := strings.Index(s, "(")
-	j := strings.Index(s, "%")
-	if i < 0 || j < 0 || j <= i {
-		return 0
-	}
-	part := strings.TrimSpace(s[i+1 : j])
-	v, _ := strconv.ParseFloat(part, 64)
-	return v
-}
-
-func parseMilli(cpu string) float64 {
-	// "123m"
-	cpu = strings.TrimSpace(strings.TrimSuffix(cpu, "m"))
-	v, _ := strconv.ParseFloat(cpu, 64)
-	return v
-}
-
-func parseMemBytes(mem string) float64 {
-	mem = strings.TrimSpace(mem)
-	if strings.HasSuffix(mem, "Gi") {
-		v, _ := strconv.ParseFloat(strings.TrimSuffix(mem, "Gi"), 64)
-		return v * 1024 * 1024 * 1024
-	}
-	if strings.HasSuffix(mem, "Mi") {
-		v, _ := strconv.ParseFloat(strings.TrimSuffix(mem, "Mi"), 64)
-		return v * 1024 * 1024
+// formatBytesHuman picks the largest of Ki/Mi/Gi/Ti that keeps the value >= 1,
+// printing whole numbers exactly and anything else with one decimal.
+// Values below 1Ki are plain bytes.
+func formatBytesHuman(b int64) string {
+	for _, u := range memoryUnits {
+		if b < u.size {
+			continue
+		}
+		if b%u.size == 0 {
+			return fmt.Sprintf("%d%s", b/u.size, u.suffix)
+		}
+		return fmt.Sprintf("%.1f%s", float64(b)/float64(u.size), u.suffix)
 	}
-	return 0
+	return strconv.FormatInt(b, 10)
 }
-
-// keep the compiler honest about imports
-var _ dynamic.Interface
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// defaultTouchAnnotation is written when k8s_touch gets no annotation. Any
+// metadata change produces a watch event, which is what makes most
+// controllers reconcile; the key only says who asked and when.
+const defaultTouchAnnotation = "mcp-kubernetes-server/reconcile-requested-at"
+
+// K8sTouch sets an annotation on any object to the current time to nudge its
+// controller into reconciling, the restartedAt trick of rollout restart
+// applied to arbitrary resources. Some controllers watch a specific key
+// (e.g. reconcile.fluxcd.io/requestedAt, argocd.argoproj.io/refresh); pass it
+// as annotation.
+//
+// Args:
+// - resource_type, name (required); namespace defaults to defaultNamespace()
+// - annotation: key to set, default mcp-kubernetes-server/reconcile-requested-at
+// - value: default the current time (RFC3339)
+func K8sTouch(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	resourceType := getStringArg(args, "resource_type", "resource")
+	name := getStringArg(args, "name", "resource_name")
+	namespace := getStringArg(args, "namespace")
+	annotation := strings.TrimSpace(getStringArg(args, "annotation"))
+	value := getStringArg(args, "value")
+
+	if strings.TrimSpace(resourceType) == "" {
+		return textErrorResult("resource_type is required"), nil, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return textErrorResult("name is required"), nil, nil
+	}
+	if annotation == "" {
+		annotation = defaultTouchAnnotation
+	}
+	if errs := validation.IsQualifiedName(annotation); len(errs) > 0 {
+		return textErrorResult(fmt.Sprintf("Error: invalid annotation key %q: %s", annotation, strings.Join(errs, "; "))), nil, nil
+	}
+	if value == "" {
+		value = time.Now().UTC().Format(time.RFC3339)
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(ctx)
+	}
+
+	disc, err := getDiscovery()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	dyn, err := getDynamic()
+	if err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+	gvr, namespaced, found := findGVR(disc, resourceType)
+	if !found {
+		return textErrorResult(fmt.Sprintf("Error: resource '%s' not found in cluster", resourceType)), nil, nil
+	}
+	if err := checkProtected(gvr, name, args, "touching"); err != nil {
+		return textErrorResult(err.Error()), nil, nil
+	}
+
+	patch, _ := json.Marshal(map[string]any{
+		"metadata": map[string]any{"annotations": map[string]string{annotation: value}},
+	})
+	ri := dyn.Resource(gvr)
+	var rv string
+	if namespaced {
+		u, err := ri.Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		rv = u.GetResourceVersion()
+	} else {
+		u, err := ri.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return textErrorResult(formatK8sErr(err)), nil, nil
+		}
+		namespace = ""
+		rv = u.GetResourceVersion()
+	}
+
+	out := map[string]any{
+		"resource":         gvr.Resource,
+		"name":             name,
+		"annotation":       annotation,
+		"value":            value,
+		"resource_version": rv,
+	}
+	if namespace != "" {
+		out["namespace"] = namespace
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	return textOKResult(string(b)), nil, nil
+}
@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// webhookInfo points at the admission webhook behind an error.
+type webhookInfo struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"` // validating or mutating
+	Configuration  string `json:"configuration"`
+	Service        string `json:"service,omitempty"` // namespace/name:port/path
+	URL            string `json:"url,omitempty"`
+	FailurePolicy  string `json:"failure_policy,omitempty"`
+	TimeoutSeconds int32  `json:"timeout_seconds,omitempty"`
+	Hint           string `json:"hint"`
+}
+
+// webhookNameRe matches both denials (admission webhook "x" denied the request)
+// and call failures (failed calling webhook "x": ...).
+var webhookNameRe = regexp.MustCompile(`webhook "([^"]+)"`)
+
+// webhookForError finds the webhook named in an admission error message in
+// the Validating/MutatingWebhookConfigurations. nil when the message names no
+// webhook; lookup failures (e.g. no RBAC to read the configurations) still
+// return the name.
+func webhookForError(ctx context.Context, msg string) *webhookInfo {
+	m := webhookNameRe.FindStringSubmatch(msg)
+	if m == nil {
+		return nil
+	}
+	info := &webhookInfo{Name: m[1]}
+	if strings.Contains(msg, "failed calling webhook") {
+		info.Hint = "The webhook could not be reached or errored; check its service/pods (a Fail policy blocks requests while it is down)."
+	} else {
+		info.Hint = "The webhook's controller rejected the object; the message after 'denied the request' is its reason."
+	}
+
+	cs, err := getClient()
+	if err != nil {
+		return info
+	}
+	fill := func(typ, config string, cc admissionv1.WebhookClientConfig, fp *admissionv1.FailurePolicyType, timeout *int32) {
+		info.Type = typ
+		info.Configuration = config
+		if s := cc.Service; s != nil {
+			info.Service = s.Namespace + "/" + s.Name
+			if s.Port != nil {
+				info.Service += fmt.Sprintf(":%d", *s.Port)
+			}
+			if s.Path != nil {
+				info.Service += *s.Path
+			}
+		}
+		if cc.URL != nil {
+			info.URL = *cc.URL
+		}
+		if fp != nil {
+			info.FailurePolicy = string(*fp)
+		}
+		if timeout != nil {
+			info.TimeoutSeconds = *timeout
+		}
+	}
+
+	if list, err := cs.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{}); err == nil {
+		for _, c := range list.Items {
+			for _, w := range c.Webhooks {
+				if w.Name == info.Name {
+					fill("validating", c.Name, w.ClientConfig, w.FailurePolicy, w.TimeoutSeconds)
+					return info
+				}
+			}
+		}
+	}
+	if list, err := cs.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{}); err == nil {
+		for _, c := range list.Items {
+			for _, w := range c.Webhooks {
+				if w.Name == info.Name {
+					fill("mutating", c.Name, w.ClientConfig, w.FailurePolicy, w.TimeoutSeconds)
+					return info
+				}
+			}
+		}
+	}
+	return info
+}